@@ -0,0 +1,113 @@
+package awsPricingTyper
+
+import "fmt"
+
+// ReservedOffer is a Reserved term of a document summarised in a single currency
+type ReservedOffer struct {
+	OfferTermCode  string
+	EffectiveDate  string
+	TermAttributes ReservedTermAttributes
+	Currency       string
+	// UpfrontFee is the one-off fee of the reservation, if any
+	UpfrontFee      float64
+	UpfrontRateCode string
+	// HourlyFee is the recurring hourly fee of the reservation, if any
+	HourlyFee      float64
+	HourlyRateCode string
+	// EffectiveHourly is the hourly fee plus the upfront fee spread evenly over the hours of the lease
+	EffectiveHourly float64
+}
+
+// OnDemandHourly returns the hourly price of the document's OnDemand term in the requested currency
+func (doc PricingDocument) OnDemandHourly(currency string) (price float64, err error) {
+	found := false
+	walkRates(doc, func(r Rate) {
+		if !found && r.TermType == TermTypeOnDemand && r.Unit == "Hrs" && r.Currency == currency {
+			price = r.Price
+			found = true
+		}
+	})
+	if !found {
+		return 0, fmt.Errorf("no on demand hourly price in %s for sku: %s", currency, doc.Product.SKU)
+	}
+	return price, nil
+}
+
+// OnDemandHourlyIn returns the hourly price of the document's OnDemand term in the requested currency,
+// converting it using the exchange rates if the term is not priced in that currency, e.g. for regions in China
+func (doc PricingDocument) OnDemandHourlyIn(currency string, rates ExchangeRateProvider) (float64, error) {
+	price, err := doc.OnDemandHourly(currency)
+	if err == nil || rates == nil {
+		return price, err
+	}
+	for _, code := range sortedOnDemandTermCodes(doc.Terms.OnDemand) {
+		for _, pd := range doc.Terms.OnDemand[code].PriceDimensions {
+			for _, rateCode := range sortedPriceDimensionRateCodes(pd) {
+				if item := pd[rateCode]; item.Unit == "Hrs" && len(item.Currencies()) > 0 {
+					return item.PriceIn(currency, rates)
+				}
+			}
+		}
+	}
+	return 0, err
+}
+
+// ReservedOffers returns the document's Reserved terms priced in the requested currency ordered by term code
+// Each term is a separate offer, even if its offer term code is missing or repeated by another term
+func (doc PricingDocument) ReservedOffers(currency string) []ReservedOffer {
+	return doc.reservedOffers(currency, func(item PriceDimensionItem) (float64, error) {
+		return item.Price(currency)
+	})
+}
+
+// ReservedOffersIn returns the document's Reserved terms in the requested currency, converting the prices
+// of terms not priced in that currency using the exchange rates
+func (doc PricingDocument) ReservedOffersIn(currency string, rates ExchangeRateProvider) []ReservedOffer {
+	if rates == nil {
+		return doc.ReservedOffers(currency)
+	}
+	return doc.reservedOffers(currency, func(item PriceDimensionItem) (float64, error) {
+		return item.PriceIn(currency, rates)
+	})
+}
+
+// reservedOffers summarises the Reserved terms with the prices of their items, omitting terms without any
+func (doc PricingDocument) reservedOffers(currency string, itemPrice func(item PriceDimensionItem) (float64, error)) (offers []ReservedOffer) {
+	for _, code := range sortedReservedTermCodes(doc.Terms.Reserved) {
+		term := doc.Terms.Reserved[code]
+		offer := ReservedOffer{
+			OfferTermCode:  term.OfferTermCode,
+			EffectiveDate:  term.EffectiveDate,
+			TermAttributes: term.TermAttributes,
+			Currency:       currency,
+		}
+		priced := false
+		for _, pd := range term.PriceDimensions {
+			for _, rateCode := range sortedPriceDimensionRateCodes(pd) {
+				item := pd[rateCode]
+				price, err := itemPrice(item)
+				if err != nil {
+					continue
+				}
+				priced = true
+				switch item.Unit {
+				case "Hrs":
+					offer.HourlyFee += price
+					offer.HourlyRateCode = rateCode
+				case "Quantity":
+					offer.UpfrontFee += price
+					offer.UpfrontRateCode = rateCode
+				}
+			}
+		}
+		if !priced {
+			continue
+		}
+		offer.EffectiveHourly = offer.HourlyFee
+		if hours := leaseHours(offer.TermAttributes.LeaseContractLength); hours > 0 {
+			offer.EffectiveHourly += offer.UpfrontFee / hours
+		}
+		offers = append(offers, offer)
+	}
+	return offers
+}
@@ -0,0 +1,102 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestOnDemandHourly(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	if price, err := doc.OnDemandHourly(CurrencyUSD); err != nil || price != 0.111 {
+		t.Errorf("unexpected on demand price: %f %+v", price, err)
+	}
+	if _, err := doc.OnDemandHourly(CurrencyCNY); err == nil {
+		t.Error("expected missing currency error")
+	}
+}
+
+func TestReservedOffers(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	doc.Terms.Reserved["7X4K64YA59VZZAC3.HU7G6KETJZ"] = ReservedTerm{
+		OfferTermCode: "HU7G6KETJZ",
+		TermAttributes: ReservedTermAttributes{
+			LeaseContractLength: "1yr",
+			OfferingClass:       "standard",
+			PurchaseOption:      "Partial Upfront",
+		},
+		PriceDimensions: []PriceDimension{{
+			"7X4K64YA59VZZAC3.HU7G6KETJZ.2TG2D8R56U": {Unit: "Quantity", PricePerUnit: []PricePerUnit{{"USD": 438}}},
+			"7X4K64YA59VZZAC3.HU7G6KETJZ.6YS6EN2CT7": {Unit: "Hrs", PricePerUnit: []PricePerUnit{{"USD": 0.05}}},
+		}},
+	}
+
+	offers := doc.ReservedOffers(CurrencyUSD)
+	if len(offers) != 2 {
+		t.Fatalf("expected 2 offers but got: %+v", offers)
+	}
+	noUpfront, partialUpfront := offers[0], offers[1]
+	if noUpfront.OfferTermCode != "4NA7Y494T4" || noUpfront.HourlyFee != 0.0756 || noUpfront.EffectiveHourly != 0.0756 {
+		t.Errorf("unexpected no upfront offer: %+v", noUpfront)
+	}
+	if partialUpfront.UpfrontFee != 438 || partialUpfront.HourlyFee != 0.05 || partialUpfront.EffectiveHourly != 0.1 {
+		t.Errorf("unexpected partial upfront offer: %+v", partialUpfront)
+	}
+	if partialUpfront.UpfrontRateCode != "7X4K64YA59VZZAC3.HU7G6KETJZ.2TG2D8R56U" {
+		t.Errorf("unexpected upfront rate code: %s", partialUpfront.UpfrontRateCode)
+	}
+	if offers = doc.ReservedOffers(CurrencyCNY); len(offers) != 0 {
+		t.Errorf("expected no offers in CNY but got: %+v", offers)
+	}
+}
+
+func TestReservedOffersWithoutOfferTermCodes(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	for _, code := range []string{"7X4K64YA59VZZAC3.A", "7X4K64YA59VZZAC3.B"} {
+		doc.Terms.Reserved[code] = ReservedTerm{
+			TermAttributes: ReservedTermAttributes{LeaseContractLength: "1yr", PurchaseOption: "All Upfront"},
+			PriceDimensions: []PriceDimension{{
+				code + ".2TG2D8R56U": {Unit: "Quantity", PricePerUnit: []PricePerUnit{{"USD": 876}}},
+			}},
+		}
+	}
+
+	offers := doc.ReservedOffers(CurrencyUSD)
+	if len(offers) != 3 {
+		t.Fatalf("expected 3 offers but got: %+v", offers)
+	}
+	for _, offer := range offers[1:] {
+		if offer.OfferTermCode != "" || offer.UpfrontFee != 876 || offer.EffectiveHourly != 0.1 {
+			t.Errorf("expected the terms' fees not to be combined but got: %+v", offer)
+		}
+	}
+}
+
+func TestPricesInOtherCurrencies(t *testing.T) {
+	rates := StaticExchangeRates{Base: CurrencyUSD, Rates: map[string]float64{CurrencyCNY: 6.9}}
+	doc := pricedInCNY(getMockPricingDocuments(t)[0], 6.9)
+	if _, err := doc.OnDemandHourly(CurrencyUSD); err == nil {
+		t.Fatal("expected missing currency error")
+	}
+	if price, err := doc.OnDemandHourlyIn(CurrencyUSD, rates); err != nil || !almostEqual(price, 0.111) {
+		t.Errorf("expected 0.111 USD but got: %f %+v", price, err)
+	}
+	if price, err := doc.OnDemandHourlyIn(CurrencyCNY, rates); err != nil || !almostEqual(price, 0.111*6.9) {
+		t.Errorf("expected the CNY price but got: %f %+v", price, err)
+	}
+	if _, err := doc.OnDemandHourlyIn(CurrencyUSD, nil); err == nil {
+		t.Error("expected missing currency error without exchange rates")
+	}
+	if _, err := doc.OnDemandHourlyIn("GBP", rates); err == nil {
+		t.Error("expected missing exchange rate error")
+	}
+
+	offers := doc.ReservedOffersIn(CurrencyUSD, rates)
+	if len(offers) != 1 || offers[0].Currency != CurrencyUSD || !almostEqual(offers[0].EffectiveHourly, 0.0756) {
+		t.Errorf("unexpected reserved offers: %+v", offers)
+	}
+	if offers = doc.ReservedOffersIn(CurrencyUSD, nil); len(offers) != 0 {
+		t.Errorf("expected no offers without exchange rates but got: %+v", offers)
+	}
+
+	metrics, err := doc.ResourceMetricsIn(CurrencyUSD, rates)
+	if err != nil || metrics.PerVCPUHour == nil || !almostEqual(*metrics.PerVCPUHour, 0.0555) {
+		t.Errorf("unexpected metrics: %+v %+v", metrics, err)
+	}
+}
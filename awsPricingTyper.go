@@ -1,94 +1,130 @@
 package awsPricingTyper
 
 import (
-	"fmt"
 	"strconv"
-
-	"reflect"
-
 	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/pricing"
 )
 
+// Options control which documents GetTypedPricingDataWithOptions returns
+// By default documents without a single, non-zero OnDemand price are suppressed
+type Options struct {
+	// KeepWithoutOnDemand keeps documents without OnDemand terms, e.g. Reserved only SKUs
+	KeepWithoutOnDemand bool
+	// KeepZeroPriced keeps documents whose OnDemand prices are all zero, e.g. BYOL variants
+	KeepZeroPriced bool
+	// KeepMultipleOnDemand keeps documents with more than one OnDemand term
+	KeepMultipleOnDemand bool
+}
+
+// SuppressionReason explains why a document was not returned
+type SuppressionReason string
+
+// Reasons for suppressing documents
+const (
+	SuppressedNoOnDemand       SuppressionReason = "no OnDemand terms"
+	SuppressedZeroPriced       SuppressionReason = "zero priced OnDemand terms"
+	SuppressedMultipleOnDemand SuppressionReason = "multiple OnDemand terms"
+)
+
+// SuppressedDocument is a document that was not returned and the reason why
+type SuppressedDocument struct {
+	SKU      string
+	Reason   SuppressionReason
+	Document PricingDocument
+}
+
 // GetTypedPricingData takes the raw output from the AWS API and returns typed data in structs
 func GetTypedPricingData(getProductsOutput pricing.GetProductsOutput) (pricingData []PricingDocument, err error) {
-	type JSONValue map[string]interface{}
+	pricingData, _, err = GetTypedPricingDataWithOptions(getProductsOutput, Options{})
+	return pricingData, err
+}
+
+// GetTypedPricingDataWithOptions takes the raw output from the AWS API and returns typed data in structs
+// along with the documents that were suppressed according to the options
+func GetTypedPricingDataWithOptions(getProductsOutput pricing.GetProductsOutput, options Options) (pricingData []PricingDocument, suppressed []SuppressedDocument, err error) {
 	for _, item := range getProductsOutput.PriceList {
-		var pDoc PricingDocument
-		for k, v := range item {
-			switch val := v.(type) {
-			case string:
-				switch k {
-				case "publicationDate":
-					pDoc.PublicationDate = val
-				case "version":
-					pDoc.Version = val
-				case "serviceCode":
-					pDoc.ServiceCode = val
-				default:
-					err = fmt.Errorf("unexpected price list item: %+v", k)
-					return
-				}
-			case map[string]interface{}:
-				switch k {
-				case "product":
-					var result Product
-					result, err = processProduct(v)
-					if err != nil {
-						return nil, err
-					}
-					pDoc.Product = result
-				case "terms":
-					proTermsErr := processTerms(&pDoc, v)
-					if proTermsErr != nil {
-						err = fmt.Errorf("failed to process terms: %+v", proTermsErr)
-						return nil, err
-					}
-				default:
-					return nil, fmt.Errorf("unexpected price list item: %+v", k)
-				}
-			default:
-				return nil, fmt.Errorf("unexpected type: %+v", val)
-			}
+		var errs errorList
+		pDoc := processPriceListItem(item, &errs)
+		pDoc.SKU = pDoc.Product.SKU
+		if err = errs.err(priceListItemSKU(item)); err != nil {
+			return nil, nil, err
 		}
 		// suppress bad onDemand documents
-		if !pDocHasValidOnDemandPricing(pDoc) {
+		if reason, ok := onDemandSuppressionReason(pDoc, options); ok {
+			suppressed = append(suppressed, SuppressedDocument{SKU: pDoc.Product.SKU, Reason: reason, Document: pDoc})
 			continue
 		}
 
 		pricingData = append(pricingData, pDoc)
 	}
-	return pricingData, err
+	return pricingData, suppressed, nil
 }
 
-func pDocHasValidOnDemandPricing(doc PricingDocument) bool {
-	hasOnDemandPrice := false
-	if len(doc.Terms.OnDemand) == 1 {
-		for _, odTerm := range doc.Terms.OnDemand {
-			for _, pDim := range odTerm.PriceDimensions {
-				for _, v := range pDim {
-					for _, a := range v.PricePerUnit {
-						for _, price := range a {
-							if price > 0.00 {
-								hasOnDemandPrice = true
-							}
-						}
-					}
-				}
+func processPriceListItem(item aws.JSONValue, errs *errorList) (pDoc PricingDocument) {
+	for _, k := range sortedKeys(item) {
+		switch val := item[k].(type) {
+		case string:
+			switch k {
+			case "publicationDate":
+				pDoc.PublicationDate, pDoc.PublicationTime = errs.asTimestamp(k, val)
+			case "version":
+				pDoc.Version = val
+			case "serviceCode":
+				pDoc.ServiceCode = val
+			default:
+				errs.add(&UnknownFieldError{Path: k, Value: val})
 			}
-
+		case map[string]interface{}:
+			switch k {
+			case "product":
+				pDoc.Product = processProduct(val, errs)
+			case "terms":
+				processTerms(&pDoc, val, errs)
+			default:
+				errs.add(&UnknownFieldError{Path: k, Value: val})
+			}
+		default:
+			errs.add(&TypeMismatchError{Path: k, Expected: "string or object", Value: val})
 		}
 	}
-	if hasOnDemandPrice {
-		return true
+	return pDoc
+}
+
+// priceListItemSKU returns the SKU of the raw price list item's product, if it has one
+func priceListItemSKU(item aws.JSONValue) string {
+	product, _ := item["product"].(map[string]interface{})
+	sku, _ := product["sku"].(string)
+	return sku
+}
+
+// onDemandSuppressionReason returns the reason a document without a single, non-zero OnDemand price
+// should be suppressed, unless the options keep such documents
+func onDemandSuppressionReason(doc PricingDocument, options Options) (SuppressionReason, bool) {
+	switch {
+	case len(doc.Terms.OnDemand) == 0:
+		return SuppressedNoOnDemand, !options.KeepWithoutOnDemand
+	case len(doc.Terms.OnDemand) > 1 && !options.KeepMultipleOnDemand:
+		return SuppressedMultipleOnDemand, true
+	}
+	hasOnDemandPrice := false
+	walkRates(doc, func(r Rate) {
+		if r.TermType == TermTypeOnDemand && r.Price > 0.00 {
+			hasOnDemandPrice = true
+		}
+	})
+	if !hasOnDemandPrice {
+		return SuppressedZeroPriced, !options.KeepZeroPriced
 	}
-	return false
+	return "", false
 }
 
-func processProduct(v interface{}) (newProduct Product, err error) {
-	for k1, v1 := range v.(map[string]interface{}) {
-		switch val := v1.(type) {
+func processProduct(product map[string]interface{}, errs *errorList) (newProduct Product) {
+	for _, k1 := range sortedKeys(product) {
+		switch val := product[k1].(type) {
 		case string:
 			switch k1 {
 			case "productFamily":
@@ -100,310 +136,286 @@ func processProduct(v interface{}) (newProduct Product, err error) {
 			case "sku":
 				newProduct.SKU = val
 			default:
-				err = fmt.Errorf("unexpected field: %+v", k1)
+				errs.add(&UnknownFieldError{Path: joinPath("product", k1), Value: val})
 			}
 		case map[string]interface{}:
-			for k2, v2 := range v1.(map[string]interface{}) {
-				switch val := v2.(type) {
-				case int64:
-					switch k2 {
-
-					}
-				case string:
-					switch k2 {
-					case "physicalCores":
-						newProduct.Attributes.PhysicalCores = val
-					case "instanceCapacity4xlarge":
-						newProduct.Attributes.InstanceCapacity4xlarge = val
-					case "instanceCapacity10xlarge":
-						newProduct.Attributes.InstanceCapacity10xlarge = val
-					case "instanceCapacity16xlarge":
-						newProduct.Attributes.InstanceCapacity16xlarge = val
-
-					case "instanceCapacity2xlarge":
-						newProduct.Attributes.InstanceCapacity2xlarge = val
-					case "instanceCapacityXlarge":
-						newProduct.Attributes.InstanceCapacityXlarge = val
-					case "instanceCapacity8xlarge":
-						newProduct.Attributes.InstanceCapacity8xlarge = val
-					case "instanceCapacityLarge":
-						newProduct.Attributes.InstanceCapacityLarge = val
-					case "networkPerformance":
-						newProduct.Attributes.NetworkPerformance = val
-					case "vcpu":
-						newProduct.Attributes.VCPU = val
-					case "gpu":
-						newProduct.Attributes.GPU = val
-					case "capacitystatus":
-						newProduct.Attributes.CapacityStatus = val
-					case "operatingSystem":
-						newProduct.Attributes.OperatingSystem = val
-					case "physicalProcessor":
-						newProduct.Attributes.PhysicalProcessor = val
-					case "ecu":
-						newProduct.Attributes.ECU = val
-					case "preInstalledSw":
-						newProduct.Attributes.PreInstalledSw = val
-					case "processorArchitecture":
-						newProduct.Attributes.ProcessorArchitecture = val
-					case "enhancedNetworkingSupported":
-						newProduct.Attributes.EnhancedNetworkingSupported = val
-					case "storage":
-						newProduct.Attributes.Storage = val
-					case "clockSpeed":
-						newProduct.Attributes.ClockSpeed = val
-					case "tenancy":
-						newProduct.Attributes.Tenancy = val
-					case "licenseModel":
-						newProduct.Attributes.LicenseModel = val
-					case "servicecode":
-						newProduct.Attributes.ServiceCode = val
-					case "currentGeneration":
-						newProduct.Attributes.CurrentGeneration = val
-					case "dedicatedEbsThroughput":
-						newProduct.Attributes.DedicatedEbsThroughput = val
-					case "servicename":
-						newProduct.Attributes.ServiceName = val
-					case "instanceType":
-						newProduct.Attributes.InstanceType = val
-					case "normalizationSizeFactor":
-						newProduct.Attributes.NormalizationSizeFactor = val
-					case "processorFeatures":
-						newProduct.Attributes.ProcessorFeatures = val
-					case "intelAvxAvailable":
-						newProduct.Attributes.IntelAvxAvailable = val
-					case "intelAvx2Available":
-						newProduct.Attributes.IntelAvx2Available = val
-					case "intelTurboAvailable":
-						newProduct.Attributes.IntelTurboAvailable = val
-					case "operation":
-						newProduct.Attributes.Operation = val
-					case "memory":
-						newProduct.Attributes.Memory = val
-					case "locationType":
-						newProduct.Attributes.LocationType = val
-					case "instanceFamily":
-						newProduct.Attributes.InstanceFamily = val
-					case "usagetype":
-						newProduct.Attributes.UsageType = val
-					case "location":
-						newProduct.Attributes.Location = val
-					default:
-						err = fmt.Errorf("unexpected attribute: %+v of type: %s", k2, reflect.TypeOf(k2))
-						return
-					}
-				}
+			for _, k2 := range sortedKeys(val) {
+				errs.add(processAttribute(&newProduct, joinPath("product", k1, k2), k2, val[k2]))
 			}
 		default:
-			err = fmt.Errorf("bad type: %+v", val)
-			return
+			errs.add(&TypeMismatchError{Path: joinPath("product", k1), Expected: "string or object", Value: val})
 		}
 	}
-	return
+	return newProduct
 }
 
-func processReservedTerms(v1 interface{}) (reservedTerms map[string]ReservedTerm, err error) {
-	reservedTerms = make(map[string]ReservedTerm)
-	for k2, v2 := range v1.(map[string]interface{}) {
-		var newReservedTerm ReservedTerm
-		switch v2.(type) {
-		case string:
-			err = fmt.Errorf("unexpected item: %+v %+v", k2, v2)
-			return
+func processAttribute(newProduct *Product, path, k2 string, v2 interface{}) error {
+	val, ok := v2.(string)
+	if !ok {
+		return &TypeMismatchError{Path: path, Expected: "string", Value: v2}
+	}
+	switch k2 {
+	case "physicalCores":
+		newProduct.Attributes.PhysicalCores = val
+	case "instanceCapacity4xlarge":
+		newProduct.Attributes.InstanceCapacity4xlarge = val
+	case "instanceCapacity10xlarge":
+		newProduct.Attributes.InstanceCapacity10xlarge = val
+	case "instanceCapacity16xlarge":
+		newProduct.Attributes.InstanceCapacity16xlarge = val
+	case "instanceCapacity2xlarge":
+		newProduct.Attributes.InstanceCapacity2xlarge = val
+	case "instanceCapacityXlarge":
+		newProduct.Attributes.InstanceCapacityXlarge = val
+	case "instanceCapacity8xlarge":
+		newProduct.Attributes.InstanceCapacity8xlarge = val
+	case "instanceCapacityLarge":
+		newProduct.Attributes.InstanceCapacityLarge = val
+	case "networkPerformance":
+		newProduct.Attributes.NetworkPerformance = val
+	case "vcpu":
+		newProduct.Attributes.VCPU = val
+	case "gpu":
+		newProduct.Attributes.GPU = val
+	case "capacitystatus":
+		newProduct.Attributes.CapacityStatus = val
+	case "operatingSystem":
+		newProduct.Attributes.OperatingSystem = val
+	case "physicalProcessor":
+		newProduct.Attributes.PhysicalProcessor = val
+	case "ecu":
+		newProduct.Attributes.ECU = val
+	case "preInstalledSw":
+		newProduct.Attributes.PreInstalledSw = val
+	case "processorArchitecture":
+		newProduct.Attributes.ProcessorArchitecture = val
+	case "enhancedNetworkingSupported":
+		newProduct.Attributes.EnhancedNetworkingSupported = val
+	case "storage":
+		newProduct.Attributes.Storage = val
+	case "clockSpeed":
+		newProduct.Attributes.ClockSpeed = val
+	case "tenancy":
+		newProduct.Attributes.Tenancy = val
+	case "licenseModel":
+		newProduct.Attributes.LicenseModel = val
+	case "servicecode":
+		newProduct.Attributes.ServiceCode = val
+	case "currentGeneration":
+		newProduct.Attributes.CurrentGeneration = val
+	case "dedicatedEbsThroughput":
+		newProduct.Attributes.DedicatedEbsThroughput = val
+	case "servicename":
+		newProduct.Attributes.ServiceName = val
+	case "instanceType":
+		newProduct.Attributes.InstanceType = val
+	case "normalizationSizeFactor":
+		newProduct.Attributes.NormalizationSizeFactor = val
+	case "processorFeatures":
+		newProduct.Attributes.ProcessorFeatures = val
+	case "intelAvxAvailable":
+		newProduct.Attributes.IntelAvxAvailable = val
+	case "intelAvx2Available":
+		newProduct.Attributes.IntelAvx2Available = val
+	case "intelTurboAvailable":
+		newProduct.Attributes.IntelTurboAvailable = val
+	case "operation":
+		newProduct.Attributes.Operation = val
+	case "memory":
+		newProduct.Attributes.Memory = val
+	case "locationType":
+		newProduct.Attributes.LocationType = val
+	case "instanceFamily":
+		newProduct.Attributes.InstanceFamily = val
+	case "usagetype":
+		newProduct.Attributes.UsageType = val
+	case "location":
+		newProduct.Attributes.Location = val
+	default:
+		return &UnknownFieldError{Path: path, Value: val}
+	}
+	return nil
+}
+
+func processTerms(doc *PricingDocument, terms map[string]interface{}, errs *errorList) {
+	for _, k1 := range sortedKeys(terms) {
+		path := joinPath("terms", k1)
+		termsByCode, ok := errs.asMap(path, terms[k1])
+		if !ok {
+			continue
+		}
+		switch k1 {
+		case "OnDemand":
+			doc.Terms.OnDemand = processOnDemandTerms(path, termsByCode, errs)
+		case "Reserved":
+			doc.Terms.Reserved = processReservedTerms(path, termsByCode, errs)
 		default:
-			for k3, v3 := range v2.(map[string]interface{}) {
-				switch val := v3.(type) {
-				case string:
-					switch k3 {
-					case "sku":
-						newReservedTerm.SKU = val
-					case "offerTermCode":
-						newReservedTerm.OfferTermCode = val
-					case "effectiveDate":
-						newReservedTerm.EffectiveDate = val
-					}
-				default:
-					if k3 == "termAttributes" {
-						for k3ta, v3ta := range v3.(map[string]interface{}) {
-							switch k3ta {
-							case "LeaseContractLength":
-								newReservedTerm.TermAttributes.LeaseContractLength = v3ta.(string)
-							case "OfferingClass":
-								newReservedTerm.TermAttributes.OfferingClass = v3ta.(string)
-							case "PurchaseOption":
-								newReservedTerm.TermAttributes.PurchaseOption = v3ta.(string)
-							}
-						}
-					} else if k3 == "priceDimensions" {
-						var newPriceDimensions []PriceDimension
-						for pdK, pdV := range v3.(map[string]interface{}) {
-							newPriceDimension := PriceDimension{}
-							switch val := pdV.(type) {
-							default:
-								err = fmt.Errorf("got unexpected price dimension value: %+v", val)
-							case map[string]interface{}:
-								var newPDItem PriceDimensionItem
-								for pdiK, pdiV := range pdV.(map[string]interface{}) {
-									switch pdiK {
-									default:
-										err = fmt.Errorf("got unexpected price dimension field: %+v", pdiK)
-									case "unit":
-										newPDItem.Unit = pdiV.(string)
-									case "pricePerUnit":
-										for pdiKu, pdiKv := range pdiV.(map[string]interface{}) {
-											pricePerUnit := make(map[string]float64)
-											pdiKvStr := pdiKv.(string)
-											pdiKvFloat, conErr := strconv.ParseFloat(pdiKvStr, 64)
-											if conErr != nil {
-												return nil, conErr
-											}
-											pricePerUnit[pdiKu] = pdiKvFloat
-											newPDItem.PricePerUnit = append(newPDItem.PricePerUnit, pricePerUnit)
-										}
-									case "appliesTo":
-										switch pdiV.(type) {
-										case map[string]interface{}:
-											// TODO: work out what to do with it
-										case []interface{}:
-											// TODO: work out what to do with it
-										default:
-											err = fmt.Errorf("unexpected type for appliesTo: %+v", pdiV)
-											return
-										}
-									case "endRange":
-										newPDItem.EndRange = pdiV.(string)
-									case "description":
-										newPDItem.Description = pdiV.(string)
-									case "rateCode":
-										newPDItem.RateCode = pdiV.(string)
-									case "beginRange":
-										newPDItem.BeginRange = pdiV.(string)
-									}
-									newPriceDimension[pdK] = newPDItem
-								}
-								newPriceDimensions = append(newPriceDimensions, newPriceDimension)
-							}
-							newReservedTerm.PriceDimensions = newPriceDimensions
+			errs.add(&UnknownFieldError{Path: path, Value: termsByCode})
+		}
+	}
+}
 
-						}
-					}
+func processOnDemandTerms(path string, terms map[string]interface{}, errs *errorList) (onDemandTerms map[string]OnDemandTerm) {
+	onDemandTerms = make(map[string]OnDemandTerm)
+	for _, k2 := range sortedKeys(terms) {
+		termPath := joinPath(path, k2)
+		term, ok := errs.asMap(termPath, terms[k2])
+		if !ok {
+			continue
+		}
+		var newOnDemandTerm OnDemandTerm
+		for _, k3 := range sortedKeys(term) {
+			fieldPath := joinPath(termPath, k3)
+			v3 := term[k3]
+			switch k3 {
+			case "sku":
+				newOnDemandTerm.SKU = errs.asString(fieldPath, v3)
+			case "offerTermCode":
+				newOnDemandTerm.OfferTermCode = errs.asString(fieldPath, v3)
+			case "effectiveDate":
+				newOnDemandTerm.EffectiveDate, newOnDemandTerm.EffectiveTime = errs.asTimestamp(fieldPath, v3)
+			case "termAttributes":
+				if termAttributes, ok := errs.asMap(fieldPath, v3); ok && len(termAttributes) > 0 {
+					errs.add(&UnknownFieldError{Path: fieldPath, Value: termAttributes})
 				}
+			case "priceDimensions":
+				newOnDemandTerm.PriceDimensions = processPriceDimensions(fieldPath, v3, false, errs)
+			default:
+				errs.add(&UnknownFieldError{Path: fieldPath, Value: v3})
 			}
 		}
+		onDemandTerms[k2] = newOnDemandTerm
+	}
+	return onDemandTerms
+}
 
+func processReservedTerms(path string, terms map[string]interface{}, errs *errorList) (reservedTerms map[string]ReservedTerm) {
+	reservedTerms = make(map[string]ReservedTerm)
+	for _, k2 := range sortedKeys(terms) {
+		termPath := joinPath(path, k2)
+		term, ok := errs.asMap(termPath, terms[k2])
+		if !ok {
+			continue
+		}
+		var newReservedTerm ReservedTerm
+		for _, k3 := range sortedKeys(term) {
+			fieldPath := joinPath(termPath, k3)
+			v3 := term[k3]
+			switch k3 {
+			case "sku":
+				newReservedTerm.SKU = errs.asString(fieldPath, v3)
+			case "offerTermCode":
+				newReservedTerm.OfferTermCode = errs.asString(fieldPath, v3)
+			case "effectiveDate":
+				newReservedTerm.EffectiveDate, newReservedTerm.EffectiveTime = errs.asTimestamp(fieldPath, v3)
+			case "termAttributes":
+				newReservedTerm.TermAttributes = processReservedTermAttributes(fieldPath, v3, errs)
+			case "priceDimensions":
+				newReservedTerm.PriceDimensions = processPriceDimensions(fieldPath, v3, true, errs)
+			default:
+				errs.add(&UnknownFieldError{Path: fieldPath, Value: v3})
+			}
+		}
 		reservedTerms[k2] = newReservedTerm
 	}
-	return
+	return reservedTerms
 }
 
-func processOnDemandTerms(v1 interface{}) (onDemandTerms map[string]OnDemandTerm, err error) {
-	onDemandTerms = make(map[string]OnDemandTerm)
-	for k2, v2 := range v1.(map[string]interface{}) {
-		var newOnDemandTerm OnDemandTerm
-		switch v2.(type) {
-		case string:
-			err = fmt.Errorf("unexpected item: %+v %+v", k2, v2)
+func processReservedTermAttributes(path string, v interface{}, errs *errorList) (termAttributes ReservedTermAttributes) {
+	attributes, ok := errs.asMap(path, v)
+	if !ok {
+		return termAttributes
+	}
+	for _, k := range sortedKeys(attributes) {
+		attributePath := joinPath(path, k)
+		switch k {
+		case "LeaseContractLength":
+			termAttributes.LeaseContractLength = errs.asString(attributePath, attributes[k])
+		case "OfferingClass":
+			termAttributes.OfferingClass = errs.asString(attributePath, attributes[k])
+		case "PurchaseOption":
+			termAttributes.PurchaseOption = errs.asString(attributePath, attributes[k])
 		default:
-			for k3, v3 := range v2.(map[string]interface{}) {
-				switch val := v3.(type) {
-				case string:
-					switch k3 {
-					case "sku":
-						newOnDemandTerm.SKU = val
-					case "offerTermCode":
-						newOnDemandTerm.OfferTermCode = val
-					case "effectiveDate":
-						newOnDemandTerm.EffectiveDate = val
+			errs.add(&UnknownFieldError{Path: attributePath, Value: attributes[k]})
+		}
+	}
+	return termAttributes
+}
+
+// processPriceDimensions converts the price dimensions of a term
+// Reserved terms may have an appliesTo object as well as an array
+func processPriceDimensions(path string, v interface{}, objectAppliesTo bool, errs *errorList) (newPriceDimensions []PriceDimension) {
+	priceDimensions, ok := errs.asMap(path, v)
+	if !ok {
+		return nil
+	}
+	for _, pdK := range sortedKeys(priceDimensions) {
+		pdPath := joinPath(path, pdK)
+		priceDimension, ok := errs.asMap(pdPath, priceDimensions[pdK])
+		if !ok {
+			continue
+		}
+		var newPDItem PriceDimensionItem
+		for _, pdiK := range sortedKeys(priceDimension) {
+			pdiPath := joinPath(pdPath, pdiK)
+			pdiV := priceDimension[pdiK]
+			switch pdiK {
+			case "unit":
+				newPDItem.Unit = errs.asString(pdiPath, pdiV)
+			case "pricePerUnit":
+				newPDItem.PricePerUnit = processPricePerUnit(pdiPath, pdiV, errs)
+			case "appliesTo":
+				switch pdiV.(type) {
+				case []interface{}:
+					// TODO: work out what to do with it
+				case map[string]interface{}:
+					if !objectAppliesTo {
+						errs.add(&TypeMismatchError{Path: pdiPath, Expected: "array", Value: pdiV})
 					}
 				default:
-					if k3 == "termAttributes" {
-						if len(v3.(map[string]interface{})) > 0 {
-							err = fmt.Errorf("unexpected term attributes for OnDemand: %+v", val)
-						}
-					} else if k3 == "priceDimensions" {
-						var newPriceDimensions []PriceDimension
-						for pdK, pdV := range v3.(map[string]interface{}) {
-							newPriceDimension := PriceDimension{}
-							switch val := pdV.(type) {
-							default:
-								err = fmt.Errorf("got unexpected price dimension value: %+v", val)
-							case map[string]interface{}:
-								var newPDItem PriceDimensionItem
-								for pdiK, pdiV := range pdV.(map[string]interface{}) {
-									switch pdiK {
-									default:
-										err = fmt.Errorf("got unexpected price dimension field: %+v", pdiK)
-									case "unit":
-										newPDItem.Unit = pdiV.(string)
-									case "pricePerUnit":
-										for pdiKu, pdiKv := range pdiV.(map[string]interface{}) {
-											pricePerUnit := make(map[string]float64)
-											pdiKvStr := pdiKv.(string)
-											pdiKvFloat, conErr := strconv.ParseFloat(pdiKvStr, 64)
-											if conErr != nil {
-												return nil, conErr
-											}
-											pricePerUnit[pdiKu] = pdiKvFloat
-											newPDItem.PricePerUnit = append(newPDItem.PricePerUnit, pricePerUnit)
-										}
-									case "endRange":
-										newPDItem.EndRange = pdiV.(string)
-									case "description":
-										newPDItem.Description = pdiV.(string)
-									case "rateCode":
-										newPDItem.RateCode = pdiV.(string)
-									case "beginRange":
-										newPDItem.BeginRange = pdiV.(string)
-									case "appliesTo":
-										switch pdiV.(type) {
-										case []interface{}:
-											// TODO: work out what to do with it
-										default:
-											err = fmt.Errorf("unexpected type for appliesTo: %+v", pdiV)
-											return nil, err
-										}
-
-									}
-									newPriceDimension[pdK] = newPDItem
-								}
-								newPriceDimensions = append(newPriceDimensions, newPriceDimension)
-							}
-							newOnDemandTerm.PriceDimensions = newPriceDimensions
-
-						}
+					expected := "array"
+					if objectAppliesTo {
+						expected = "array or object"
 					}
+					errs.add(&TypeMismatchError{Path: pdiPath, Expected: expected, Value: pdiV})
 				}
+			case "endRange":
+				newPDItem.EndRange = errs.asString(pdiPath, pdiV)
+			case "description":
+				newPDItem.Description = errs.asString(pdiPath, pdiV)
+			case "rateCode":
+				newPDItem.RateCode = errs.asString(pdiPath, pdiV)
+			case "beginRange":
+				newPDItem.BeginRange = errs.asString(pdiPath, pdiV)
+			default:
+				errs.add(&UnknownFieldError{Path: pdiPath, Value: pdiV})
 			}
 		}
-		onDemandTerms[k2] = newOnDemandTerm
+		newPriceDimensions = append(newPriceDimensions, PriceDimension{pdK: newPDItem})
 	}
-	return
+	return newPriceDimensions
 }
 
-func processTerms(doc *PricingDocument, v interface{}) error {
-	for k1, v1 := range v.(map[string]interface{}) {
-		switch v1.(type) {
-		case map[string]interface{}:
-			switch k1 {
-			case "OnDemand":
-				result, err := processOnDemandTerms(v1)
-				if err != nil {
-					return err
-				}
-				doc.Terms.OnDemand = result
-			case "Reserved":
-				result, err := processReservedTerms(v1)
-				if err != nil {
-					return err
-				}
-				doc.Terms.Reserved = result
-
-			}
-		default:
-			return fmt.Errorf("did not expect value: %+v", v1)
+func processPricePerUnit(path string, v interface{}, errs *errorList) (pricesPerUnit []PricePerUnit) {
+	prices, ok := errs.asMap(path, v)
+	if !ok {
+		return nil
+	}
+	for _, currency := range sortedKeys(prices) {
+		pricePath := joinPath(path, currency)
+		priceStr, ok := prices[currency].(string)
+		if !ok {
+			errs.add(&TypeMismatchError{Path: pricePath, Expected: "string", Value: prices[currency]})
+			continue
+		}
+		price, conErr := strconv.ParseFloat(priceStr, 64)
+		if conErr != nil {
+			errs.add(&PriceParseError{Path: pricePath, Value: priceStr, Err: conErr})
+			continue
 		}
+		pricesPerUnit = append(pricesPerUnit, PricePerUnit{currency: price})
 	}
-	return nil
+	return pricesPerUnit
 }
 
 //type onDemandTermAttributes struct {
@@ -413,6 +425,8 @@ func processTerms(doc *PricingDocument, v interface{}) error {
 type OnDemandTerm struct {
 	SKU           string
 	EffectiveDate string
+	// EffectiveTime is EffectiveDate parsed
+	EffectiveTime time.Time
 	OfferTermCode string
 	//TermAttributes  OnDemandTermAttributes
 	PriceDimensions []PriceDimension
@@ -433,17 +447,22 @@ type PriceDimensionItem struct {
 type PriceDimension map[string]PriceDimensionItem
 
 type ReservedTerm struct {
-	SKU            string
-	EffectiveDate  string
-	OfferTermCode  string
-	TermAttributes struct {
-		LeaseContractLength string
-		OfferingClass       string
-		PurchaseOption      string
-	}
+	SKU           string
+	EffectiveDate string
+	// EffectiveTime is EffectiveDate parsed
+	EffectiveTime   time.Time
+	OfferTermCode   string
+	TermAttributes  ReservedTermAttributes
 	PriceDimensions []PriceDimension
 }
 
+// ReservedTermAttributes describes the lease of a Reserved term
+type ReservedTermAttributes struct {
+	LeaseContractLength string
+	OfferingClass       string
+	PurchaseOption      string
+}
+
 type Product struct {
 	ProductFamily string
 	SKU           string
@@ -493,6 +512,8 @@ type Product struct {
 // representing each resulting product and it's accompanying pricing detail
 type PricingDocument struct {
 	PublicationDate string
+	// PublicationTime is PublicationDate parsed
+	PublicationTime time.Time
 	SKU             string
 	ServiceCode     string
 	Version         string
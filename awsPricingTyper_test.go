@@ -1,7 +1,9 @@
 package awsPricingTyper
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/pricing"
@@ -100,7 +102,11 @@ func getMockTerms() map[string]interface{} {
 	onDemandTermOne["termAttributes"] = onDemandTermOneAttrs
 	onDemandPriceDimensionOne := make(map[string]interface{})
 	onDemandPriceDimensionOnePricePerUnit := make(map[string]interface{})
-	onDemandPriceDimensionOnePricePerUnit["USD"] = "0.1110000000"
+	if mockTermsFailure == "zeroPricedOnDemand" {
+		onDemandPriceDimensionOnePricePerUnit["USD"] = "0.0000000000"
+	} else {
+		onDemandPriceDimensionOnePricePerUnit["USD"] = "0.1110000000"
+	}
 	onDemandPriceDimensionOne["pricePerUnit"] = onDemandPriceDimensionOnePricePerUnit
 	onDemandPriceDimensions := make(map[string]interface{})
 	onDemandPriceDimensionOne["unit"] = "Hrs"
@@ -113,6 +119,9 @@ func getMockTerms() map[string]interface{} {
 	onDemandPriceDimensions["ABCDEFGHIJK.LMNOPQRST.UVWXYZ"] = onDemandPriceDimensionOne
 	onDemandTermOne["priceDimensions"] = onDemandPriceDimensions
 	onDemandTerms["7X4K64YA59VZZAC3.JRTCKXETXF"] = onDemandTermOne
+	if mockTermsFailure == "multipleOnDemand" {
+		onDemandTerms["7X4K64YA59VZZAC3.MZU6U2429S"] = onDemandTermOne
+	}
 
 	reservedTerms := make(map[string]interface{})
 	reservedTermOneAttrs := make(map[string]interface{})
@@ -139,7 +148,9 @@ func getMockTerms() map[string]interface{} {
 	reservedPriceDimensions["7X4K64YA59VZZAC3.4NA7Y494T4.6YS6EN2CT7"] = reservedPriceDimensionOne
 	reservedTermOne["priceDimensions"] = reservedPriceDimensions
 	reservedTerms["7X4K64YA59VZZAC3.4NA7Y494T4"] = reservedTermOne
-	terms["OnDemand"] = onDemandTerms
+	if mockTermsFailure != "noOnDemand" {
+		terms["OnDemand"] = onDemandTerms
+	}
 	if mockTermsFailure == "unexpectedTypeForTerms" {
 		terms["Reserved"] = "badTypeValue"
 	} else {
@@ -218,6 +229,24 @@ func TestTyperWithGoodData(t *testing.T) {
 
 }
 
+func TestTyperPopulatesSKUAndTimestamps(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	if doc.SKU != "7X4K64YA59VZZAC3" {
+		t.Errorf("expected document sku to be populated but got: %s", doc.SKU)
+	}
+	if doc.PublicationDate != "2018-07-27T01:58:36Z" || !doc.PublicationTime.Equal(time.Date(2018, 7, 27, 1, 58, 36, 0, time.UTC)) {
+		t.Errorf("unexpected publication date: %s %v", doc.PublicationDate, doc.PublicationTime)
+	}
+	onDemand := doc.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"]
+	if onDemand.EffectiveDate != "2018-07-01T00:00:00Z" || !onDemand.EffectiveTime.Equal(time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected on demand effective date: %s %v", onDemand.EffectiveDate, onDemand.EffectiveTime)
+	}
+	reserved := doc.Terms.Reserved["7X4K64YA59VZZAC3.4NA7Y494T4"]
+	if !reserved.EffectiveTime.Equal(time.Date(2017, 4, 30, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("unexpected reserved effective date: %s %v", reserved.EffectiveDate, reserved.EffectiveTime)
+	}
+}
+
 // client failing with unimplemented product family item
 func TestTyperWithUnimplementedProductFamily(t *testing.T) {
 	mockProductFailure = "unimplementedProductFamily"
@@ -418,6 +447,123 @@ func TestAppliesToWithInvalidTypeOnDemand(t *testing.T) {
 
 }
 
+// reset all mock failure flags so the mock client returns good data
+func resetMockFailures() {
+	mockPriceListFailureItem = false
+	mockPriceListFailureItemProduct = false
+	mockPriceListFailureStringType = false
+	mockPriceListFailureMapType = false
+	mockPriceListFailureFloatType = false
+	mockPriceListUnexpectedItem = false
+	mockProductFailure = "good"
+	mockTermsFailure = ""
+}
+
+// get typed pricing documents from the working mock client
+func getMockPricingDocuments(t *testing.T) []PricingDocument {
+	resetMockFailures()
+	mockSvc := &mockPricingClient{}
+	getProductsOutput, getProductsErr := mockSvc.GetProducts(&pricing.GetProductsInput{})
+	if getProductsErr != nil {
+		t.Fatalf("got unexpected error: %+v", getProductsErr)
+	}
+	pricingData, getDataErr := GetTypedPricingData(*getProductsOutput)
+	if getDataErr != nil {
+		t.Fatalf("got error: %+v", getDataErr)
+	}
+	return pricingData
+}
+
+// get a mock document with its on demand price replaced
+func getMockDocumentWithOnDemandPrice(t *testing.T, sku string, price float64) PricingDocument {
+	doc := getMockPricingDocuments(t)[0]
+	doc.Product.SKU = sku
+	item := PriceDimensionItem{Unit: "Hrs", PricePerUnit: []PricePerUnit{{"USD": price}}}
+	doc.Terms.OnDemand = map[string]OnDemandTerm{
+		sku + ".JRTCKXETXF": {
+			SKU:             sku,
+			OfferTermCode:   "JRTCKXETXF",
+			PriceDimensions: []PriceDimension{{sku + ".JRTCKXETXF.6YS6EN2CT7": item}},
+		},
+	}
+	doc.Terms.Reserved = nil
+	return doc
+}
+
+// get a mock instance document of the instance type, also used as its sku, with the on demand price
+func getMockInstanceDocument(t *testing.T, instanceType, vcpu, memory string, onDemand float64) PricingDocument {
+	doc := getMockDocumentWithOnDemandPrice(t, instanceType, onDemand)
+	doc.Product.Attributes.InstanceType = instanceType
+	doc.Product.Attributes.VCPU = vcpu
+	doc.Product.Attributes.Memory = memory
+	return doc
+}
+
+// almostEqual compares calculated prices allowing for floating point error
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// convert the prices of a document from USD to CNY as in regions in China
+func pricedInCNY(doc PricingDocument, rate float64) PricingDocument {
+	for _, term := range doc.Terms.OnDemand {
+		convertPriceDimensions(term.PriceDimensions, rate)
+	}
+	for _, term := range doc.Terms.Reserved {
+		convertPriceDimensions(term.PriceDimensions, rate)
+	}
+	return doc
+}
+
+func convertPriceDimensions(priceDimensions []PriceDimension, rate float64) {
+	for _, pd := range priceDimensions {
+		for rateCode, item := range pd {
+			item.PricePerUnit = []PricePerUnit{{CurrencyCNY: item.PricePerUnit[0][CurrencyUSD] * rate}}
+			pd[rateCode] = item
+		}
+	}
+}
+
+// documents without valid on demand pricing are suppressed unless kept by the options
+func TestTyperSuppressionOptions(t *testing.T) {
+	for _, tc := range []struct {
+		mockTermsFailure string
+		reason           SuppressionReason
+		options          Options
+	}{
+		{"noOnDemand", SuppressedNoOnDemand, Options{KeepWithoutOnDemand: true}},
+		{"zeroPricedOnDemand", SuppressedZeroPriced, Options{KeepZeroPriced: true}},
+		{"multipleOnDemand", SuppressedMultipleOnDemand, Options{KeepMultipleOnDemand: true}},
+	} {
+		resetMockFailures()
+		mockTermsFailure = tc.mockTermsFailure
+		mockSvc := &mockPricingClient{}
+		getProductsOutput, getProductsErr := mockSvc.GetProducts(&pricing.GetProductsInput{})
+		if getProductsErr != nil {
+			t.Errorf("got unexpected error: %+v", getProductsErr)
+		}
+
+		pricingData, suppressed, getDataErr := GetTypedPricingDataWithOptions(*getProductsOutput, Options{})
+		if getDataErr != nil {
+			t.Errorf("got error: %+v", getDataErr)
+		}
+		if len(pricingData) != 0 || len(suppressed) != 1 || suppressed[0].Reason != tc.reason {
+			t.Errorf("%s: expected document to be suppressed with reason %q but got: %+v", tc.mockTermsFailure, tc.reason, suppressed)
+		}
+		if len(suppressed) == 1 && suppressed[0].SKU != "7X4K64YA59VZZAC3" {
+			t.Errorf("%s: unexpected suppressed sku: %s", tc.mockTermsFailure, suppressed[0].SKU)
+		}
+
+		pricingData, suppressed, getDataErr = GetTypedPricingDataWithOptions(*getProductsOutput, tc.options)
+		if getDataErr != nil {
+			t.Errorf("got error: %+v", getDataErr)
+		}
+		if len(pricingData) != 1 || len(suppressed) != 0 {
+			t.Errorf("%s: expected document to be kept but got: %+v", tc.mockTermsFailure, suppressed)
+		}
+	}
+}
+
 func getStrPtr(input string) *string {
 	return &input
 }
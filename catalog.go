@@ -0,0 +1,234 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"sort"
+)
+
+// catalogField is an attribute of a document indexed by a Catalog
+type catalogField int
+
+const (
+	catalogInstanceType catalogField = iota
+	catalogLocation
+	catalogOperatingSystem
+	catalogTenancy
+	catalogLicenseModel
+	catalogPreInstalledSw
+	catalogFieldCount
+)
+
+func (f catalogField) value(doc *PricingDocument) string {
+	attrs := &doc.Product.Attributes
+	switch f {
+	case catalogInstanceType:
+		return attrs.InstanceType
+	case catalogLocation:
+		return attrs.Location
+	case catalogOperatingSystem:
+		return attrs.OperatingSystem
+	case catalogTenancy:
+		return attrs.Tenancy
+	case catalogLicenseModel:
+		return attrs.LicenseModel
+	case catalogPreInstalledSw:
+		return attrs.PreInstalledSw
+	}
+	return ""
+}
+
+// CatalogQuery selects documents from a Catalog
+// Fields are matched exactly and empty fields match any document
+type CatalogQuery struct {
+	SKU             string
+	InstanceType    string
+	Location        string
+	OperatingSystem string
+	Tenancy         string
+	LicenseModel    string
+	PreInstalledSw  string
+}
+
+func (q CatalogQuery) values() [catalogFieldCount]string {
+	return [catalogFieldCount]string{
+		catalogInstanceType:    q.InstanceType,
+		catalogLocation:        q.Location,
+		catalogOperatingSystem: q.OperatingSystem,
+		catalogTenancy:         q.Tenancy,
+		catalogLicenseModel:    q.LicenseModel,
+		catalogPreInstalledSw:  q.PreInstalledSw,
+	}
+}
+
+// Catalog is an index of PricingDocuments by SKU and product attributes
+// The documents are copied when the catalog is built, so it can be read by concurrent goroutines while
+// the caller changes its own documents, but those returned by the catalog share their terms with it
+// and must not be modified
+type Catalog struct {
+	docs    []PricingDocument
+	bySKU   map[string]int
+	indexes [catalogFieldCount]map[string][]int
+	// byInstance indexes documents by instance type, location, operating system and tenancy,
+	// the attributes set by lookups of an instance
+	byInstance map[[4]string][]int
+}
+
+// NewCatalog indexes copies of the documents, returning an error if more than one has the same SKU
+func NewCatalog(docs []PricingDocument) (*Catalog, error) {
+	c := &Catalog{
+		docs:       make([]PricingDocument, len(docs)),
+		bySKU:      make(map[string]int, len(docs)),
+		byInstance: make(map[[4]string][]int),
+	}
+	for i, doc := range docs {
+		c.docs[i] = copyTerms(doc)
+	}
+	for f := range c.indexes {
+		c.indexes[f] = make(map[string][]int)
+	}
+	for i := range c.docs {
+		doc := &c.docs[i]
+		sku := doc.Product.SKU
+		if _, exists := c.bySKU[sku]; exists {
+			return nil, fmt.Errorf("duplicate sku in catalog: %s", sku)
+		}
+		c.bySKU[sku] = i
+		for f := range c.indexes {
+			value := catalogField(f).value(doc)
+			c.indexes[f][value] = append(c.indexes[f][value], i)
+		}
+		attrs := &doc.Product.Attributes
+		key := instanceKey(attrs.InstanceType, attrs.Location, attrs.OperatingSystem, attrs.Tenancy)
+		c.byInstance[key] = append(c.byInstance[key], i)
+	}
+	return c, nil
+}
+
+// Len returns the number of documents in the catalog
+func (c *Catalog) Len() int {
+	return len(c.docs)
+}
+
+// Get returns the document with the SKU
+func (c *Catalog) Get(sku string) (PricingDocument, bool) {
+	i, ok := c.bySKU[sku]
+	if !ok {
+		return PricingDocument{}, false
+	}
+	return c.docs[i], true
+}
+
+// Query returns the documents matching every non-empty field of the query in the order they were added
+// The sorted lists of documents matching each field are intersected starting from the shortest, so the
+// cost depends on the number of documents matching the most selective field rather than the catalog size
+func (c *Catalog) Query(q CatalogQuery) (docs []PricingDocument) {
+	if q.SKU != "" {
+		doc, ok := c.Get(q.SKU)
+		if ok && c.matches(&doc, q) {
+			docs = append(docs, doc)
+		}
+		return docs
+	}
+	var postings [][]int
+	if q.InstanceType != "" && q.Location != "" && q.OperatingSystem != "" && q.Tenancy != "" {
+		postings = append(postings, c.byInstance[instanceKey(q.InstanceType, q.Location, q.OperatingSystem, q.Tenancy)])
+	}
+	for f, value := range q.values() {
+		if value != "" {
+			postings = append(postings, c.indexes[f][value])
+		}
+	}
+	if len(postings) == 0 {
+		docs = make([]PricingDocument, len(c.docs))
+		copy(docs, c.docs)
+		return docs
+	}
+	for _, i := range intersectPostings(postings) {
+		docs = append(docs, c.docs[i])
+	}
+	return docs
+}
+
+// intersectPostings returns the indexes in every one of the ascending lists
+// Each index of the shortest list is searched for in the rest, which are only searched beyond
+// the previous match
+func intersectPostings(postings [][]int) (matches []int) {
+	sort.Slice(postings, func(i, j int) bool {
+		return len(postings[i]) < len(postings[j])
+	})
+	offsets := make([]int, len(postings))
+candidates:
+	for _, i := range postings[0] {
+		for p := 1; p < len(postings); p++ {
+			rest := postings[p][offsets[p]:]
+			n := sort.SearchInts(rest, i)
+			offsets[p] += n
+			if n == len(rest) {
+				return matches
+			}
+			if rest[n] != i {
+				continue candidates
+			}
+		}
+		matches = append(matches, i)
+	}
+	return matches
+}
+
+func instanceKey(instanceType, location, operatingSystem, tenancy string) [4]string {
+	return [4]string{instanceType, location, operatingSystem, tenancy}
+}
+
+func (c *Catalog) matches(doc *PricingDocument, q CatalogQuery) bool {
+	for f, value := range q.values() {
+		if value != "" && catalogField(f).value(doc) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// copyTerms returns a copy of the document that shares none of its terms with the original
+func copyTerms(doc PricingDocument) PricingDocument {
+	onDemand, reserved := doc.Terms.OnDemand, doc.Terms.Reserved
+	doc.Terms.OnDemand, doc.Terms.Reserved = nil, nil
+	if onDemand != nil {
+		doc.Terms.OnDemand = make(map[string]OnDemandTerm, len(onDemand))
+		for code, term := range onDemand {
+			term.PriceDimensions = copyPriceDimensions(term.PriceDimensions)
+			doc.Terms.OnDemand[code] = term
+		}
+	}
+	if reserved != nil {
+		doc.Terms.Reserved = make(map[string]ReservedTerm, len(reserved))
+		for code, term := range reserved {
+			term.PriceDimensions = copyPriceDimensions(term.PriceDimensions)
+			doc.Terms.Reserved[code] = term
+		}
+	}
+	return doc
+}
+
+func copyPriceDimensions(priceDimensions []PriceDimension) []PriceDimension {
+	if priceDimensions == nil {
+		return nil
+	}
+	copied := make([]PriceDimension, len(priceDimensions))
+	for i, pd := range priceDimensions {
+		copied[i] = make(PriceDimension, len(pd))
+		for rateCode, item := range pd {
+			pricesPerUnit := item.PricePerUnit
+			if pricesPerUnit != nil {
+				item.PricePerUnit = make([]PricePerUnit, len(pricesPerUnit))
+			}
+			for j, ppu := range pricesPerUnit {
+				item.PricePerUnit[j] = make(PricePerUnit, len(ppu))
+				for currency, price := range ppu {
+					item.PricePerUnit[j][currency] = price
+				}
+			}
+			copied[i][rateCode] = item
+		}
+	}
+	return copied
+}
@@ -0,0 +1,147 @@
+package awsPricingTyper
+
+import (
+	"sync"
+	"testing"
+)
+
+func getMockCatalogDocuments(t *testing.T) []PricingDocument {
+	linux := getMockInstanceDocument(t, "m4.large", "2", "8 GiB", 0.111)
+	windows := getMockInstanceDocument(t, "m4.large", "2", "8 GiB", 0.204)
+	windows.Product.SKU = "m4.large.windows"
+	windows.Product.Attributes.OperatingSystem = "Windows"
+	windows.Product.Attributes.LicenseModel = "License Included"
+	sql := windows
+	sql.Product.SKU = "m4.large.sql"
+	sql.Product.Attributes.PreInstalledSw = "SQL Std"
+	dedicated := getMockInstanceDocument(t, "m4.xlarge", "4", "16 GiB", 0.222)
+	dedicated.Product.Attributes.Tenancy = "Dedicated"
+	frankfurt := getMockInstanceDocument(t, "c4.large", "2", "3.75 GiB", 0.114)
+	frankfurt.Product.Attributes.Location = "EU (Frankfurt)"
+	return []PricingDocument{linux, windows, sql, dedicated, frankfurt}
+}
+
+func catalogSKUs(docs []PricingDocument) (skus []string) {
+	for _, doc := range docs {
+		skus = append(skus, doc.Product.SKU)
+	}
+	return skus
+}
+
+func TestCatalogQuery(t *testing.T) {
+	catalog, err := NewCatalog(getMockCatalogDocuments(t))
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if catalog.Len() != 5 {
+		t.Errorf("expected 5 documents but got: %d", catalog.Len())
+	}
+	if doc, ok := catalog.Get("m4.large.sql"); !ok || doc.Product.Attributes.PreInstalledSw != "SQL Std" {
+		t.Errorf("unexpected document for sku: %+v", doc)
+	}
+	if _, ok := catalog.Get("missing"); ok {
+		t.Error("expected no document for missing sku")
+	}
+
+	tests := []struct {
+		query    CatalogQuery
+		expected []string
+	}{
+		{CatalogQuery{}, []string{"m4.large", "m4.large.windows", "m4.large.sql", "m4.xlarge", "c4.large"}},
+		{CatalogQuery{InstanceType: "m4.large"}, []string{"m4.large", "m4.large.windows", "m4.large.sql"}},
+		{CatalogQuery{InstanceType: "m4.large", OperatingSystem: "Windows", PreInstalledSw: "NA"}, []string{"m4.large.windows"}},
+		{CatalogQuery{Location: "EU (Ireland)", Tenancy: "Dedicated"}, []string{"m4.xlarge"}},
+		{CatalogQuery{Location: "EU (Frankfurt)", LicenseModel: "No License required"}, []string{"c4.large"}},
+		{CatalogQuery{SKU: "m4.large.sql", OperatingSystem: "Windows"}, []string{"m4.large.sql"}},
+		{CatalogQuery{SKU: "m4.large.sql", OperatingSystem: "Linux"}, nil},
+		{CatalogQuery{InstanceType: "m4.large", Location: "EU (Frankfurt)"}, nil},
+		{CatalogQuery{InstanceType: "p3.2xlarge"}, nil},
+		{CatalogQuery{InstanceType: "m4.large", Location: "EU (Ireland)", OperatingSystem: "Windows", Tenancy: "Shared"}, []string{"m4.large.windows", "m4.large.sql"}},
+		{CatalogQuery{InstanceType: "m4.large", Location: "EU (Ireland)", OperatingSystem: "Windows", Tenancy: "Shared", PreInstalledSw: "SQL Std"}, []string{"m4.large.sql"}},
+		{CatalogQuery{InstanceType: "m4.xlarge", Location: "EU (Ireland)", OperatingSystem: "Linux", Tenancy: "Shared"}, nil},
+	}
+	for _, test := range tests {
+		got := catalogSKUs(catalog.Query(test.query))
+		if len(got) != len(test.expected) {
+			t.Errorf("query %+v: expected %v but got: %v", test.query, test.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("query %+v: expected %v but got: %v", test.query, test.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestCatalogConcurrentReaders(t *testing.T) {
+	docs := getMockCatalogDocuments(t)
+	catalog, err := NewCatalog(docs)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				docs := catalog.Query(CatalogQuery{InstanceType: "m4.large", OperatingSystem: "Windows"})
+				if len(docs) != 2 {
+					t.Errorf("expected 2 documents but got: %d", len(docs))
+					return
+				}
+				if price, err := docs[0].OnDemandHourly(CurrencyUSD); err != nil || price != 0.204 {
+					t.Errorf("unexpected price: %f %+v", price, err)
+					return
+				}
+			}
+		}()
+	}
+	// the catalog's documents do not share terms with the caller's
+	for _, doc := range docs {
+		for _, term := range doc.Terms.OnDemand {
+			for _, pd := range term.PriceDimensions {
+				for rateCode, item := range pd {
+					item.PricePerUnit[0][CurrencyUSD] = 1
+					pd[rateCode] = item
+				}
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func TestIntersectPostings(t *testing.T) {
+	tests := []struct {
+		postings [][]int
+		expected []int
+	}{
+		{[][]int{{0, 2, 4, 6, 8}, {1, 2, 3, 4}, {2, 4, 9}}, []int{2, 4}},
+		{[][]int{{5, 6, 7}, {0, 1, 2, 3, 4, 5, 6, 7}}, []int{5, 6, 7}},
+		{[][]int{{0, 1}, {2, 3}}, nil},
+		{[][]int{{0, 1}, {}}, nil},
+		{[][]int{{1, 3, 4}}, []int{1, 3, 4}},
+	}
+	for _, test := range tests {
+		got := intersectPostings(test.postings)
+		if len(got) != len(test.expected) {
+			t.Errorf("expected %v but got: %v", test.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("expected %v but got: %v", test.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestCatalogDuplicateSKU(t *testing.T) {
+	docs := getMockCatalogDocuments(t)
+	if _, err := NewCatalog(append(docs, docs[0])); err == nil {
+		t.Error("expected error for duplicate sku")
+	}
+}
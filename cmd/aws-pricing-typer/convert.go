@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+// runConvert types saved GetProducts output read from files, or stdin, without calling the API
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aws-pricing-typer convert [flags] [file ...]")
+		fmt.Fprintln(stderr, "reads stdin if no files, or '-', are given")
+		fs.PrintDefaults()
+	}
+	outputPath := fs.String("output", "", "write typed JSON to this file instead of stdout")
+	lines := fs.Bool("lines", false, "write one JSON document per line instead of an array")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	docs := []awsPricingTyper.PricingDocument{}
+	for _, path := range paths {
+		fileDocs, err := convertFile(path, stdin)
+		if err != nil {
+			return fmt.Errorf("%s: %+v", path, err)
+		}
+		docs = append(docs, fileDocs...)
+	}
+
+	out := stdout
+	if *outputPath != "" {
+		f, err := os.Create(*outputPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if !*lines {
+		return writeJSON(out, docs)
+	}
+	enc := json.NewEncoder(out)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertFile(path string, stdin io.Reader) ([]awsPricingTyper.PricingDocument, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	output, err := awsPricingTyper.ReadGetProductsOutput(r)
+	if err != nil {
+		return nil, err
+	}
+	return awsPricingTyper.GetTypedPricingData(output)
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+func TestConvertFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"convert", "testdata/get-products.json"}, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	var docs []awsPricingTyper.PricingDocument
+	if err := json.Unmarshal(stdout.Bytes(), &docs); err != nil {
+		t.Fatalf("failed to decode json: %+v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents but got: %d", len(docs))
+	}
+	if docs[0].Product.Attributes.InstanceType != "m4.large" {
+		t.Errorf("unexpected instance type: %s", docs[0].Product.Attributes.InstanceType)
+	}
+}
+
+func TestConvertStdinLines(t *testing.T) {
+	input, err := os.ReadFile("testdata/get-products.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// two concatenated pages
+	stdin := bytes.NewReader(append(append([]byte{}, input...), input...))
+	var stdout, stderr bytes.Buffer
+	if err = run([]string{"convert", "-lines"}, stdin, &stdout, &stderr); err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 4 {
+		t.Errorf("expected 4 documents but got: %d", len(lines))
+	}
+}
+
+func TestConvertInvalidInput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"convert", "-"}, strings.NewReader(`{"PriceList": [1]}`), &stdout, &stderr); err == nil {
+		t.Error("expected invalid price list item error")
+	}
+	if err := run([]string{"convert", "testdata/missing.json"}, nil, &stdout, &stderr); err == nil {
+		t.Error("expected missing file error")
+	}
+}
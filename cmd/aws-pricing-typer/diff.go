@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+// runDiff compares two price lists and reports added and removed SKUs and rates and changed prices
+func runDiff(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aws-pricing-typer diff [flags] <old file> <new file>")
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", formatTable, "output format: table or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expected two files to compare")
+	}
+	if *format != formatTable && *format != formatJSON {
+		return fmt.Errorf("unsupported format: %s", *format)
+	}
+
+	oldDocs, err := loadDocuments(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	newDocs, err := loadDocuments(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	diff := awsPricingTyper.DiffPricingDocuments(oldDocs, newDocs)
+	if *format == formatJSON {
+		return writeJSON(stdout, diff)
+	}
+	return writeDiffTable(stdout, diff)
+}
+
+func writeDiffTable(w io.Writer, diff awsPricingTyper.PriceListDiff) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "old version:\t%s\t%s\n", diff.OldVersion, diff.OldPublicationDate)
+	fmt.Fprintf(tw, "new version:\t%s\t%s\n", diff.NewVersion, diff.NewPublicationDate)
+	for _, sku := range diff.AddedSKUs {
+		fmt.Fprintf(tw, "added:\t%s\n", sku)
+	}
+	for _, sku := range diff.RemovedSKUs {
+		fmt.Fprintf(tw, "removed:\t%s\n", sku)
+	}
+	for _, r := range diff.AddedRates {
+		fmt.Fprintf(tw, "added rate:\t%s\t%s\t%s\t%g\n", r.SKU, r.RateCode, r.Currency, r.Price)
+	}
+	for _, r := range diff.RemovedRates {
+		fmt.Fprintf(tw, "removed rate:\t%s\t%s\t%s\t%g\n", r.SKU, r.RateCode, r.Currency, r.Price)
+	}
+	for _, c := range diff.PriceChanges {
+		fmt.Fprintf(tw, "changed:\t%s\t%s\t%s\t%g -> %g\t%+.2f%%\n",
+			c.SKU, c.RateCode, c.Currency, c.OldPrice, c.NewPrice, c.PercentChange)
+	}
+	return tw.Flush()
+}
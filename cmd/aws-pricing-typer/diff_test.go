@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+func TestDiffOfferFiles(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"diff", "testdata/offer.json", "testdata/offer-new.json"}, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	out := stdout.String()
+	for _, expected := range []string{
+		"DQ578CGN99KG6ECF",
+		"removed:      8VCNEHQMSCQS4P39",
+		"0.111 -> 0.1",
+		"-9.91%",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q but got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestDiffConvertedAgainstRaw(t *testing.T) {
+	var converted, stderr bytes.Buffer
+	if err := run([]string{"convert", "testdata/get-products.json"}, nil, &converted, &stderr); err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	dir := t.TempDir()
+	typedPath := dir + "/typed.json"
+	if err := os.WriteFile(typedPath, converted.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := run([]string{"diff", "-format", "json", typedPath, "testdata/offer.json"}, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	var diff awsPricingTyper.PriceListDiff
+	if err := json.Unmarshal(stdout.Bytes(), &diff); err != nil {
+		t.Fatalf("failed to decode json: %+v", err)
+	}
+	if len(diff.AddedSKUs)+len(diff.RemovedSKUs)+len(diff.AddedRates)+len(diff.RemovedRates)+len(diff.PriceChanges) != 0 {
+		t.Errorf("expected no differences but got: %+v", diff)
+	}
+}
+
+func TestDiffInvalidArguments(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"diff", "testdata/offer.json"}, nil, &stdout, &stderr); err == nil {
+		t.Error("expected missing file argument error")
+	}
+	if err := run([]string{"diff", "testdata/offer.json", "main.go"}, nil, &stdout, &stderr); err == nil {
+		t.Error("expected unrecognised input error")
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+// formatPeekSize is how much of the start of a file is examined to detect its format
+// The keys identifying each format precede any large values so are found well within it
+const formatPeekSize = 64 * 1024
+
+// input formats that can be loaded
+const (
+	inputTyped       = "typed"
+	inputTypedLines  = "typed lines"
+	inputOfferFile   = "offer file"
+	inputGetProducts = "get products"
+)
+
+// loadDocuments reads pricing documents from a file containing any of:
+// an offer file, saved GetProducts output, or typed JSON written by the convert command
+// The format is detected from the start of the file, which is then decoded as a stream
+func loadDocuments(path string) ([]awsPricingTyper.PricingDocument, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := bufio.NewReaderSize(f, formatPeekSize)
+	prefix, err := r.Peek(formatPeekSize)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s: %+v", path, err)
+	}
+	format, err := detectFormat(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %+v", path, err)
+	}
+	switch format {
+	case inputTyped:
+		return decodeDocuments(path, r)
+	case inputOfferFile:
+		return typeOutput(path, r, awsPricingTyper.ReadOfferFile)
+	case inputGetProducts:
+		return typeOutput(path, r, awsPricingTyper.ReadGetProductsOutput)
+	default:
+		return decodeDocumentLines(path, r)
+	}
+}
+
+// detectFormat returns the format of a file from its start, either a JSON array of typed documents or
+// an object with a top-level key identifying its format
+func detectFormat(prefix []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(prefix))
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode: %+v", err)
+	}
+	if tok == json.Delim('[') {
+		return inputTyped, nil
+	}
+	if tok != json.Delim('{') {
+		return "", fmt.Errorf("unrecognised pricing data")
+	}
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			break
+		}
+		switch tok {
+		case "products":
+			return inputOfferFile, nil
+		case "PriceList":
+			return inputGetProducts, nil
+		case "Product":
+			return inputTypedLines, nil
+		}
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			break
+		}
+	}
+	return "", fmt.Errorf("unrecognised pricing data")
+}
+
+func typeOutput(path string, r io.Reader, read func(io.Reader) (pricing.GetProductsOutput, error)) ([]awsPricingTyper.PricingDocument, error) {
+	output, err := read(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %+v", path, err)
+	}
+	return awsPricingTyper.GetTypedPricingData(output)
+}
+
+// decodeDocuments decodes a JSON array of typed documents one element at a time
+func decodeDocuments(path string, r io.Reader) (docs []awsPricingTyper.PricingDocument, err error) {
+	dec := json.NewDecoder(r)
+	if _, err = dec.Token(); err != nil {
+		return nil, fmt.Errorf("%s: failed to decode typed documents: %+v", path, err)
+	}
+	for dec.More() {
+		var doc awsPricingTyper.PricingDocument
+		if err = dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode typed documents: %+v", path, err)
+		}
+		docs = append(docs, doc)
+	}
+	if _, err = dec.Token(); err != nil {
+		return nil, fmt.Errorf("%s: failed to decode typed documents: %+v", path, err)
+	}
+	return docs, nil
+}
+
+func decodeDocumentLines(path string, r io.Reader) (docs []awsPricingTyper.PricingDocument, err error) {
+	dec := json.NewDecoder(r)
+	for {
+		var doc awsPricingTyper.PricingDocument
+		err = dec.Decode(&doc)
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to decode typed document: %+v", path, err)
+		}
+		docs = append(docs, doc)
+	}
+}
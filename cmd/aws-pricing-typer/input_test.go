@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{` [{"SKU": "7X4K64YA59VZZAC3"}]`, inputTyped},
+		{`{"PublicationDate": "2018-07-27T01:58:36Z", "SKU": "7X4K64YA59VZZAC3", "Product": {"SKU": "7X4K`, inputTypedLines},
+		// the prefix ends part way through the products
+		{`{"formatVersion": "v1.0", "disclaimer": "This pricing list is for informational purposes only.", "products": {"7X4K64YA59VZZAC3": {"sku"`, inputOfferFile},
+		{`{"FormatVersion": "aws_v1", "NextToken": null, "PriceList": ["{\"product\"`, inputGetProducts},
+	}
+	for _, test := range tests {
+		format, err := detectFormat([]byte(test.prefix))
+		if err != nil || format != test.expected {
+			t.Errorf("expected %s for %s but got: %s %+v", test.expected, test.prefix, format, err)
+		}
+	}
+	for _, prefix := range []string{``, `"products"`, `{"formatVersion": "v1.0"}`, `{"formatVersion": "v1.0", "offerCode": "Amazon`} {
+		if _, err := detectFormat([]byte(prefix)); err == nil {
+			t.Errorf("expected error for %s", prefix)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Command aws-pricing-typer queries the AWS Pricing API, or reads local pricing data,
+// and prints the typed results as a table, JSON or CSV
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: aws-pricing-typer <command> [flags]
+
+commands:
+  query    query prices and print the typed results
+  convert  convert saved GetProducts JSON output to typed JSON
+  diff     compare two price lists and report changes
+
+run 'aws-pricing-typer <command> -h' for the flags of each command
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("no command specified")
+	}
+	switch args[0] {
+	case "query":
+		return runQuery(args[1:], stdout, stderr)
+	case "convert":
+		return runConvert(args[1:], stdin, stdout, stderr)
+	case "diff":
+		return runDiff(args[1:], stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+var rowHeader = []string{
+	"SKU", "InstanceType", "Location", "OperatingSystem", "Tenancy", "TermType",
+	"OfferTermCode", "LeaseContractLength", "OfferingClass", "PurchaseOption",
+	"Unit", "Currency", "Price",
+}
+
+func validFormat(format string) bool {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return true
+	}
+	return false
+}
+
+func writeDocuments(w io.Writer, format string, docs []awsPricingTyper.PricingDocument) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(w, docs)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(rowHeader); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(documentRows(docs)); err != nil {
+			return err
+		}
+		return cw.Error()
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(rowHeader, "\t"))
+		for _, row := range documentRows(docs) {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// documentRows returns a row for each price of each document
+func documentRows(docs []awsPricingTyper.PricingDocument) (rows [][]string) {
+	for _, doc := range docs {
+		attrs := doc.Product.Attributes
+		for _, r := range doc.Rates() {
+			ta := r.TermAttributes
+			rows = append(rows, []string{
+				r.SKU, attrs.InstanceType, attrs.Location, attrs.OperatingSystem, attrs.Tenancy, r.TermType,
+				r.OfferTermCode, ta.LeaseContractLength, ta.OfferingClass, ta.PurchaseOption,
+				r.Unit, r.Currency, strconv.FormatFloat(r.Price, 'f', -1, 64),
+			})
+		}
+	}
+	return rows
+}
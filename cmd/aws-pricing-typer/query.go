@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+// the pricing API is only available in a few regions
+const pricingAPIRegion = "us-east-1"
+
+type queryCriteria struct {
+	serviceCode     string
+	location        string
+	instanceType    string
+	operatingSystem string
+	tenancy         string
+}
+
+func runQuery(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	service := fs.String("service", "AmazonEC2", "service code to query")
+	region := fs.String("region", "", "region code, e.g. eu-west-1")
+	instanceType := fs.String("instance-type", "", "instance type, e.g. m4.large")
+	operatingSystem := fs.String("os", "", "operating system, e.g. Linux")
+	tenancy := fs.String("tenancy", "", "tenancy, e.g. Shared")
+	offerFile := fs.String("offer-file", "", "read prices from a local offer file instead of the API")
+	rawFile := fs.String("raw-file", "", "read prices from saved GetProducts JSON output instead of the API")
+	format := fs.String("format", formatTable, "output format: table, json or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !validFormat(*format) {
+		return fmt.Errorf("unsupported format: %s", *format)
+	}
+
+	criteria := queryCriteria{
+		serviceCode:     *service,
+		instanceType:    *instanceType,
+		operatingSystem: *operatingSystem,
+		tenancy:         *tenancy,
+	}
+	if *region != "" {
+		r, ok := awsPricingTyper.RegionByCode(*region)
+		if !ok {
+			return fmt.Errorf("unknown region: %s", *region)
+		}
+		criteria.location = r.Location
+	}
+
+	var output pricing.GetProductsOutput
+	var err error
+	switch {
+	case *offerFile != "":
+		output, err = readFile(*offerFile, awsPricingTyper.ReadOfferFile)
+	case *rawFile != "":
+		output, err = readFile(*rawFile, awsPricingTyper.ReadGetProductsOutput)
+	default:
+		output, err = getProducts(criteria)
+	}
+	if err != nil {
+		return err
+	}
+
+	docs, err := awsPricingTyper.GetTypedPricingData(output)
+	if err != nil {
+		return err
+	}
+	return writeDocuments(stdout, *format, filterDocuments(docs, criteria))
+}
+
+func readFile(path string, read func(io.Reader) (pricing.GetProductsOutput, error)) (output pricing.GetProductsOutput, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return output, err
+	}
+	defer f.Close()
+	return read(f)
+}
+
+func getProducts(criteria queryCriteria) (output pricing.GetProductsOutput, err error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(pricingAPIRegion)})
+	if err != nil {
+		return output, err
+	}
+	svc := pricing.New(sess)
+	input := &pricing.GetProductsInput{
+		ServiceCode:   aws.String(criteria.serviceCode),
+		FormatVersion: aws.String("aws_v1"),
+		Filters:       criteria.filters(),
+	}
+	err = svc.GetProductsPages(input, func(page *pricing.GetProductsOutput, lastPage bool) bool {
+		output.PriceList = append(output.PriceList, page.PriceList...)
+		output.FormatVersion = page.FormatVersion
+		return true
+	})
+	return output, err
+}
+
+func (c queryCriteria) filters() (filters []*pricing.Filter) {
+	fields := []struct {
+		field string
+		value string
+	}{
+		{"location", c.location},
+		{"instanceType", c.instanceType},
+		{"operatingSystem", c.operatingSystem},
+		{"tenancy", c.tenancy},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		filters = append(filters, &pricing.Filter{
+			Type:  aws.String(pricing.FilterTypeTermMatch),
+			Field: aws.String(f.field),
+			Value: aws.String(f.value),
+		})
+	}
+	return filters
+}
+
+// filterDocuments applies the criteria to documents read from local files
+func filterDocuments(docs []awsPricingTyper.PricingDocument, c queryCriteria) (filtered []awsPricingTyper.PricingDocument) {
+	for _, doc := range docs {
+		attrs := doc.Product.Attributes
+		switch {
+		case c.serviceCode != "" && doc.ServiceCode != c.serviceCode:
+		case c.location != "" && attrs.Location != c.location:
+		case c.instanceType != "" && attrs.InstanceType != c.instanceType:
+		case c.operatingSystem != "" && attrs.OperatingSystem != c.operatingSystem:
+		case c.tenancy != "" && attrs.Tenancy != c.tenancy:
+		default:
+			filtered = append(filtered, doc)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jonhadfield/aws-pricing-typer"
+)
+
+func TestQueryOfferFileTable(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"query", "-offer-file", "testdata/offer.json", "-os", "Linux"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and two rows but got:\n%s", stdout.String())
+	}
+	if !strings.Contains(lines[1], "OnDemand") || !strings.Contains(lines[1], "0.111") {
+		t.Errorf("unexpected on demand row: %s", lines[1])
+	}
+	if !strings.Contains(lines[2], "No Upfront") || !strings.Contains(lines[2], "0.0756") {
+		t.Errorf("unexpected reserved row: %s", lines[2])
+	}
+}
+
+func TestQueryOfferFileCSV(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"query", "-offer-file", "testdata/offer.json", "-region", "eu-west-1", "-format", "csv"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	records, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read csv: %+v", err)
+	}
+	if len(records) != 4 {
+		t.Errorf("expected header and three rows but got: %d", len(records))
+	}
+}
+
+func TestQueryOfferFileJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"query", "-offer-file", "testdata/offer.json", "-os", "Windows", "-format", "json"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	var docs []awsPricingTyper.PricingDocument
+	if err = json.Unmarshal(stdout.Bytes(), &docs); err != nil {
+		t.Fatalf("failed to decode json: %+v", err)
+	}
+	if len(docs) != 1 || docs[0].Product.SKU != "8VCNEHQMSCQS4P39" {
+		t.Errorf("unexpected documents: %+v", docs)
+	}
+}
+
+func TestQueryInvalidArguments(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"query", "-region", "moon-1"}, nil, &stdout, &stderr); err == nil {
+		t.Error("expected unknown region error")
+	}
+	if err := run([]string{"query", "-format", "xml"}, nil, &stdout, &stderr); err == nil {
+		t.Error("expected unsupported format error")
+	}
+	if err := run([]string{"unknown"}, nil, &stdout, &stderr); err == nil {
+		t.Error("expected unknown command error")
+	}
+}
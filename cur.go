@@ -0,0 +1,166 @@
+package awsPricingTyper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// CURLineItem is the pricing detail of a Cost and Usage Report line item
+type CURLineItem struct {
+	// Line is the line of the report the item was read from
+	Line          int
+	UsageType     string
+	Operation     string
+	SKU           string
+	RateCode      string
+	Currency      string
+	UnblendedRate float64
+}
+
+// columns of a Cost and Usage Report read into CURLineItems
+const (
+	curUsageType     = "lineItem/UsageType"
+	curOperation     = "lineItem/Operation"
+	curSKU           = "product/sku"
+	curRateCode      = "pricing/rateCode"
+	curCurrency      = "lineItem/CurrencyCode"
+	curUnblendedRate = "lineItem/UnblendedRate"
+)
+
+// ReadCURLineItems reads the line items of a Cost and Usage Report CSV
+// The currency column is optional and line items without one are assumed to be in USD
+func ReadCURLineItems(r io.Reader) (items []CURLineItem, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cost and usage report header: %+v", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[name] = i
+	}
+	for _, name := range []string{curUsageType, curOperation, curSKU, curRateCode, curUnblendedRate} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("cost and usage report has no %s column", name)
+		}
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+	for line := 2; ; line++ {
+		var record []string
+		record, err = cr.Read()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cost and usage report: %+v", err)
+		}
+		item := CURLineItem{
+			Line:      line,
+			UsageType: field(record, curUsageType),
+			Operation: field(record, curOperation),
+			SKU:       field(record, curSKU),
+			RateCode:  field(record, curRateCode),
+			Currency:  field(record, curCurrency),
+		}
+		if item.Currency == "" {
+			item.Currency = CurrencyUSD
+		}
+		if rate := field(record, curUnblendedRate); rate != "" {
+			if item.UnblendedRate, err = strconv.ParseFloat(rate, 64); err != nil {
+				return nil, fmt.Errorf("failed to parse unblended rate %q on line %d: %+v", rate, line, err)
+			}
+		}
+		items = append(items, item)
+	}
+}
+
+// DiscrepancyReason is the reason a line item does not reconcile with the price list
+type DiscrepancyReason string
+
+// Reasons for discrepancies between line items and the price list
+const (
+	DiscrepancyUnknownSKU      DiscrepancyReason = "sku not in price list"
+	DiscrepancyUnknownRateCode DiscrepancyReason = "rate code not in price list"
+	DiscrepancyUsageType       DiscrepancyReason = "usage type differs"
+	DiscrepancyOperation       DiscrepancyReason = "operation differs"
+	DiscrepancyRate            DiscrepancyReason = "rate differs from list price"
+)
+
+// Discrepancy is a line item that does not reconcile with the price list
+type Discrepancy struct {
+	LineItem CURLineItem
+	Reason   DiscrepancyReason
+	// ListRate is the price list rate of the line item's rate code, if found
+	ListRate *Rate
+	// ListUsageType and ListOperation are the attributes of the line item's SKU, if found
+	ListUsageType string
+	ListOperation string
+}
+
+// curRateTolerance allows for the rounding of rates in reports
+const curRateTolerance = 1e-9
+
+// Reconcile compares line items with the OnDemand and Reserved rates of the catalog's documents and
+// returns those whose SKU, rate code, usage type, operation or unblended rate disagree with the price list
+// Line items without a SKU or rate code, such as taxes and credits, are skipped
+func Reconcile(catalog *Catalog, items []CURLineItem) (discrepancies []Discrepancy) {
+	for _, item := range items {
+		if item.SKU == "" || item.RateCode == "" {
+			continue
+		}
+		doc, ok := catalog.Get(item.SKU)
+		if !ok {
+			discrepancies = append(discrepancies, Discrepancy{LineItem: item, Reason: DiscrepancyUnknownSKU})
+			continue
+		}
+		d := Discrepancy{
+			LineItem:      item,
+			ListUsageType: doc.Product.Attributes.UsageType,
+			ListOperation: doc.Product.Attributes.Operation,
+		}
+		walkRates(doc, func(r Rate) {
+			if d.ListRate == nil && r.RateCode == item.RateCode && r.Currency == item.Currency {
+				rate := r
+				d.ListRate = &rate
+			}
+		})
+		switch {
+		case d.ListRate == nil:
+			d.Reason = DiscrepancyUnknownRateCode
+		case !sameUsageType(item.UsageType, d.ListUsageType):
+			d.Reason = DiscrepancyUsageType
+		case item.Operation != "" && item.Operation != d.ListOperation:
+			d.Reason = DiscrepancyOperation
+		case math.Abs(item.UnblendedRate-d.ListRate.Price) > curRateTolerance*math.Max(1, d.ListRate.Price):
+			d.Reason = DiscrepancyRate
+		default:
+			continue
+		}
+		discrepancies = append(discrepancies, d)
+	}
+	return discrepancies
+}
+
+// sameUsageType compares usage types allowing for us-east-1 usage types with and without a region prefix
+func sameUsageType(a, b string) bool {
+	if a == "" || a == b {
+		return true
+	}
+	utA, errA := ParseUsageType(a)
+	utB, errB := ParseUsageType(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return utA.RegionCode == utB.RegionCode && utA.Kind == utB.Kind &&
+		utA.InstanceType == utB.InstanceType && utA.InstanceFamily == utB.InstanceFamily
+}
@@ -0,0 +1,68 @@
+package awsPricingTyper
+
+import (
+	"strings"
+	"testing"
+)
+
+const mockCUR = `identity/LineItemId,lineItem/UsageType,lineItem/Operation,product/sku,pricing/rateCode,lineItem/UnblendedRate,lineItem/CurrencyCode
+1,EU-BoxUsage:m4.large,RunInstances,7X4K64YA59VZZAC3,ABCDEFGHIJK.LMNOPQRST.UVWXYZ,0.1110000000,USD
+2,EU-BoxUsage:m4.large,RunInstances,7X4K64YA59VZZAC3,ABCDEFGHIJK.LMNOPQRST.UVWXYZ,0.0999000000,USD
+3,EU-BoxUsage:m4.large,RunInstances,7X4K64YA59VZZAC3,7X4K64YA59VZZAC3.4NA7Y494T4.6YS6EN2CT7,0.0756000000,USD
+4,EU-BoxUsage:m4.large,RunInstances,UNKNOWNSKU,UNKNOWNSKU.JRTCKXETXF.6YS6EN2CT7,0.1110000000,USD
+5,EU-BoxUsage:m4.large,RunInstances,7X4K64YA59VZZAC3,7X4K64YA59VZZAC3.JRTCKXETXF.UNKNOWN,0.1110000000,USD
+6,EU-BoxUsage:m4.xlarge,RunInstances,7X4K64YA59VZZAC3,ABCDEFGHIJK.LMNOPQRST.UVWXYZ,0.1110000000,USD
+7,EU-BoxUsage:m4.large,RunInstances:0002,7X4K64YA59VZZAC3,ABCDEFGHIJK.LMNOPQRST.UVWXYZ,0.1110000000,USD
+8,,,,,1.50,USD
+`
+
+func TestReconcile(t *testing.T) {
+	items, err := ReadCURLineItems(strings.NewReader(mockCUR))
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if len(items) != 8 || items[1].Line != 3 || items[1].UnblendedRate != 0.0999 {
+		t.Fatalf("unexpected line items: %+v", items)
+	}
+	catalog, err := NewCatalog(getMockPricingDocuments(t))
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+
+	discrepancies := Reconcile(catalog, items)
+	expected := []struct {
+		line   int
+		reason DiscrepancyReason
+	}{
+		{3, DiscrepancyRate},
+		{5, DiscrepancyUnknownSKU},
+		{6, DiscrepancyUnknownRateCode},
+		{7, DiscrepancyUsageType},
+		{8, DiscrepancyOperation},
+	}
+	if len(discrepancies) != len(expected) {
+		t.Fatalf("expected %d discrepancies but got: %+v", len(expected), discrepancies)
+	}
+	for i, e := range expected {
+		if discrepancies[i].LineItem.Line != e.line || discrepancies[i].Reason != e.reason {
+			t.Errorf("expected line %d to be %q but got: %+v", e.line, e.reason, discrepancies[i])
+		}
+	}
+	if rate := discrepancies[0].ListRate; rate == nil || rate.Price != 0.111 || rate.TermType != TermTypeOnDemand {
+		t.Errorf("unexpected list rate: %+v", rate)
+	}
+}
+
+func TestReadCURLineItemsErrors(t *testing.T) {
+	if _, err := ReadCURLineItems(strings.NewReader("lineItem/UsageType,product/sku\n")); err == nil {
+		t.Error("expected error for missing columns")
+	}
+	header := "lineItem/UsageType,lineItem/Operation,product/sku,pricing/rateCode,lineItem/UnblendedRate\n"
+	items, err := ReadCURLineItems(strings.NewReader(header + "BoxUsage:m4.large,RunInstances,SKU,SKU.A.B,\n"))
+	if err != nil || len(items) != 1 || items[0].Currency != CurrencyUSD {
+		t.Errorf("unexpected line items: %+v %+v", items, err)
+	}
+	if _, err = ReadCURLineItems(strings.NewReader(header + "BoxUsage:m4.large,RunInstances,SKU,SKU.A.B,cheap\n")); err == nil {
+		t.Error("expected error for bad unblended rate")
+	}
+}
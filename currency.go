@@ -0,0 +1,123 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Currencies used by the price lists
+// Regions in China are priced in CNY and all others in USD
+const (
+	CurrencyUSD = "USD"
+	CurrencyCNY = "CNY"
+)
+
+// ExchangeRateProvider converts prices between currencies
+type ExchangeRateProvider interface {
+	// Rate returns the number of units of currency to that one unit of currency from is worth
+	Rate(from, to string) (float64, error)
+}
+
+// StaticExchangeRates is an ExchangeRateProvider backed by a fixed table of rates
+// relative to a base currency, e.g. Base USD with Rates {"CNY": 6.9}
+type StaticExchangeRates struct {
+	Base  string
+	Rates map[string]float64
+}
+
+// Rate returns the exchange rate between two currencies in the table
+func (s StaticExchangeRates) Rate(from, to string) (float64, error) {
+	fromRate, err := s.baseRate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := s.baseRate(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+func (s StaticExchangeRates) baseRate(currency string) (float64, error) {
+	if currency == s.Base {
+		return 1, nil
+	}
+	rate, ok := s.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no exchange rate for %s against %s", currency, s.Base)
+	}
+	return rate, nil
+}
+
+// Currencies returns the currencies the price is available in
+func (p PricePerUnit) Currencies() []string {
+	currencies := make([]string, 0, len(p))
+	for currency := range p {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// Price returns the price in the requested currency
+func (p PricePerUnit) Price(currency string) (float64, error) {
+	price, ok := p[currency]
+	if !ok {
+		return 0, fmt.Errorf("no price in %s, available currencies: %v", currency, p.Currencies())
+	}
+	return price, nil
+}
+
+// Currencies returns the currencies the item is priced in
+func (item PriceDimensionItem) Currencies() (currencies []string) {
+	seen := make(map[string]bool)
+	for _, ppu := range item.PricePerUnit {
+		for currency := range ppu {
+			if !seen[currency] {
+				seen[currency] = true
+				currencies = append(currencies, currency)
+			}
+		}
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// Price returns the item's price in the requested currency
+func (item PriceDimensionItem) Price(currency string) (float64, error) {
+	for _, ppu := range item.PricePerUnit {
+		if price, ok := ppu[currency]; ok {
+			return price, nil
+		}
+	}
+	return 0, fmt.Errorf("no price in %s for rate code: %s, available currencies: %v",
+		currency, item.RateCode, item.Currencies())
+}
+
+// PriceIn returns the item's price in the requested currency, converting it using
+// the exchange rates if the item is not priced in that currency
+// USD prices are preferred as the source of conversion when available
+func (item PriceDimensionItem) PriceIn(currency string, rates ExchangeRateProvider) (float64, error) {
+	if price, err := item.Price(currency); err == nil {
+		return price, nil
+	}
+	currencies := item.Currencies()
+	if len(currencies) == 0 {
+		return 0, fmt.Errorf("no prices for rate code: %s", item.RateCode)
+	}
+	from := currencies[0]
+	for _, c := range currencies {
+		if c == CurrencyUSD {
+			from = c
+		}
+	}
+	price, err := item.Price(from)
+	if err != nil {
+		return 0, err
+	}
+	rate, err := rates.Rate(from, currency)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert price for rate code: %s from %s to %s: %+v", item.RateCode, from, currency, err)
+	}
+	return price * rate, nil
+}
@@ -0,0 +1,39 @@
+package awsPricingTyper
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPriceDimensionItemPrice(t *testing.T) {
+	item := PriceDimensionItem{RateCode: "A.B.C", PricePerUnit: []PricePerUnit{{"CNY": 0.69}}}
+	if price, err := item.Price(CurrencyCNY); err != nil || price != 0.69 {
+		t.Errorf("unexpected price: %f %+v", price, err)
+	}
+	if _, err := item.Price(CurrencyUSD); err == nil {
+		t.Error("expected missing currency error")
+	}
+	if _, err := item.PricePerUnit[0].Price(CurrencyUSD); err == nil {
+		t.Error("expected missing currency error")
+	}
+}
+
+func TestPriceDimensionItemPriceIn(t *testing.T) {
+	rates := StaticExchangeRates{Base: CurrencyUSD, Rates: map[string]float64{CurrencyCNY: 6.9, "EUR": 0.85}}
+	item := PriceDimensionItem{RateCode: "A.B.C", PricePerUnit: []PricePerUnit{{"CNY": 0.69}}}
+
+	price, err := item.PriceIn(CurrencyUSD, rates)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if math.Abs(price-0.1) > 1e-9 {
+		t.Errorf("expected 0.1 USD but got: %f", price)
+	}
+	// converted between two non-base currencies
+	if price, err = item.PriceIn("EUR", rates); err != nil || math.Abs(price-0.085) > 1e-9 {
+		t.Errorf("expected 0.085 EUR but got: %f %+v", price, err)
+	}
+	if _, err = item.PriceIn("GBP", rates); err == nil {
+		t.Error("expected missing exchange rate error")
+	}
+}
@@ -0,0 +1,92 @@
+package awsPricingTyper
+
+import "fmt"
+
+// HostInstanceSize is the capacity of a Dedicated Host for one instance size and what each instance costs
+type HostInstanceSize struct {
+	InstanceType string
+	Capacity     int
+	// PerInstanceHourly is the host's on-demand hourly price divided between a full host of the size
+	PerInstanceHourly float64
+	// HasShared is false if no shared tenancy Linux document was found for the instance type
+	HasShared bool
+	// SharedOnDemandHourly is the on-demand hourly price of the instance type with shared tenancy
+	SharedOnDemandHourly float64
+}
+
+// HostCapacity is the capacity and per-instance cost of a Dedicated Host packed with a single instance size
+type HostCapacity struct {
+	InstanceFamily string
+	PhysicalCores  int
+	Currency       string
+	OnDemandHourly float64
+	// Sizes are ordered from smallest to largest and only include sizes the host can run
+	Sizes []HostInstanceSize
+}
+
+// hostSizes are the instance sizes of the Dedicated Host capacity attributes from smallest to largest
+var hostSizes = []struct {
+	size     string
+	capacity func(doc PricingDocument) string
+}{
+	{"large", func(doc PricingDocument) string { return doc.Product.Attributes.InstanceCapacityLarge }},
+	{"xlarge", func(doc PricingDocument) string { return doc.Product.Attributes.InstanceCapacityXlarge }},
+	{"2xlarge", func(doc PricingDocument) string { return doc.Product.Attributes.InstanceCapacity2xlarge }},
+	{"4xlarge", func(doc PricingDocument) string { return doc.Product.Attributes.InstanceCapacity4xlarge }},
+	{"8xlarge", func(doc PricingDocument) string { return doc.Product.Attributes.InstanceCapacity8xlarge }},
+	{"10xlarge", func(doc PricingDocument) string { return doc.Product.Attributes.InstanceCapacity10xlarge }},
+	{"16xlarge", func(doc PricingDocument) string { return doc.Product.Attributes.InstanceCapacity16xlarge }},
+}
+
+// DedicatedHostCapacity returns the number of instances of each size a Dedicated Host document can run and
+// the effective hourly cost of each when the host is full, in the currency
+// If a catalog is given each size is compared with the on-demand price of the same Linux instance type
+// with shared tenancy in the host's location
+func DedicatedHostCapacity(host PricingDocument, shared *Catalog, currency string) (capacity HostCapacity, err error) {
+	if host.Product.ProductFamily != "Dedicated Host" {
+		return capacity, fmt.Errorf("sku: %s is not a Dedicated Host but: %s", host.Product.SKU, host.Product.ProductFamily)
+	}
+	if capacity.OnDemandHourly, err = host.OnDemandHourly(currency); err != nil {
+		return capacity, err
+	}
+	attrs := host.Product.Attributes
+	capacity.InstanceFamily = attrs.InstanceType
+	capacity.PhysicalCores = attributeInt(attrs.PhysicalCores)
+	capacity.Currency = currency
+	for _, hs := range hostSizes {
+		n := attributeInt(hs.capacity(host))
+		if n <= 0 {
+			continue
+		}
+		size := HostInstanceSize{
+			InstanceType:      attrs.InstanceType + "." + hs.size,
+			Capacity:          n,
+			PerInstanceHourly: capacity.OnDemandHourly / float64(n),
+		}
+		if shared != nil {
+			if size.SharedOnDemandHourly, err = sharedOnDemandHourly(shared, size.InstanceType, attrs.Location, currency); err == nil {
+				size.HasShared = true
+			}
+		}
+		capacity.Sizes = append(capacity.Sizes, size)
+	}
+	if len(capacity.Sizes) == 0 {
+		return capacity, fmt.Errorf("no instance capacity for sku: %s", host.Product.SKU)
+	}
+	return capacity, nil
+}
+
+// sharedOnDemandHourly returns the on-demand hourly price of a running Linux instance with shared tenancy
+func sharedOnDemandHourly(catalog *Catalog, instanceType, location, currency string) (float64, error) {
+	doc, err := catalog.lookupRunning(InstanceSpec{
+		InstanceType:    instanceType,
+		Location:        location,
+		OperatingSystem: "Linux",
+		Tenancy:         TenancyShared,
+		PreInstalledSw:  "NA",
+	})
+	if err != nil {
+		return 0, err
+	}
+	return doc.OnDemandHourly(currency)
+}
@@ -0,0 +1,46 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestDedicatedHostCapacity(t *testing.T) {
+	host := getMockDocumentWithOnDemandPrice(t, "HOSTSKU", 2.2)
+	host.Product.ProductFamily = "Dedicated Host"
+	host.Product.Attributes.InstanceType = "m4"
+	host.Product.Attributes.Tenancy = TenancyHost
+	host.Product.Attributes.PhysicalCores = "24"
+	host.Product.Attributes.InstanceCapacityLarge = "22"
+	host.Product.Attributes.InstanceCapacityXlarge = "11"
+	host.Product.Attributes.InstanceCapacity2xlarge = "5"
+	shared, err := NewCatalog([]PricingDocument{
+		getMockInstanceDocument(t, "m4.large", "2", "8 GiB", 0.111),
+		getMockInstanceDocument(t, "m4.xlarge", "4", "16 GiB", 0.222),
+	})
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+
+	capacity, err := DedicatedHostCapacity(host, shared, CurrencyUSD)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if capacity.InstanceFamily != "m4" || capacity.PhysicalCores != 24 || capacity.OnDemandHourly != 2.2 || len(capacity.Sizes) != 3 {
+		t.Fatalf("unexpected capacity: %+v", capacity)
+	}
+	large, xlarge, xxlarge := capacity.Sizes[0], capacity.Sizes[1], capacity.Sizes[2]
+	if large.InstanceType != "m4.large" || large.Capacity != 22 || !almostEqual(large.PerInstanceHourly, 0.1) {
+		t.Errorf("unexpected large size: %+v", large)
+	}
+	if !large.HasShared || large.SharedOnDemandHourly != 0.111 || !xlarge.HasShared || xlarge.SharedOnDemandHourly != 0.222 {
+		t.Errorf("unexpected shared prices: %+v %+v", large, xlarge)
+	}
+	if xxlarge.InstanceType != "m4.2xlarge" || !almostEqual(xxlarge.PerInstanceHourly, 0.44) || xxlarge.HasShared {
+		t.Errorf("unexpected 2xlarge size: %+v", xxlarge)
+	}
+
+	if capacity, err = DedicatedHostCapacity(host, nil, CurrencyUSD); err != nil || capacity.Sizes[0].HasShared {
+		t.Errorf("expected no shared comparison without a catalog: %+v %+v", capacity, err)
+	}
+	if _, err = DedicatedHostCapacity(getMockPricingDocuments(t)[0], shared, CurrencyUSD); err == nil {
+		t.Error("expected error for compute instance document")
+	}
+}
@@ -0,0 +1,142 @@
+package awsPricingTyper
+
+import "sort"
+
+// PriceListDiff describes the differences between two sets of pricing documents
+type PriceListDiff struct {
+	OldVersion         string
+	NewVersion         string
+	OldPublicationDate string
+	NewPublicationDate string
+	AddedSKUs          []string
+	RemovedSKUs        []string
+	// AddedRates and RemovedRates are the rates of SKUs in both versions that only appear in one of them,
+	// e.g. a Reserved offer that has been withdrawn
+	AddedRates   []Rate
+	RemovedRates []Rate
+	PriceChanges []PriceChange
+}
+
+// PriceChange is a price, identified by SKU, OfferTermCode, RateCode and Currency, that differs between versions
+type PriceChange struct {
+	SKU           string
+	TermType      string
+	OfferTermCode string
+	RateCode      string
+	Currency      string
+	OldPrice      float64
+	NewPrice      float64
+	// PercentChange is the change relative to OldPrice and is zero if OldPrice is zero
+	PercentChange float64
+}
+
+type priceKey struct {
+	sku           string
+	offerTermCode string
+	rateCode      string
+	currency      string
+}
+
+// DiffPricingDocuments compares two sets of pricing documents, typically two publications of the same
+// price list, and reports SKUs and rates that have been added or removed and prices that have changed
+func DiffPricingDocuments(oldDocs, newDocs []PricingDocument) (diff PriceListDiff) {
+	diff.OldVersion, diff.OldPublicationDate = latestVersion(oldDocs)
+	diff.NewVersion, diff.NewPublicationDate = latestVersion(newDocs)
+
+	oldSKUs, oldPrices := indexPrices(oldDocs)
+	newSKUs, newPrices := indexPrices(newDocs)
+	for sku := range newSKUs {
+		if !oldSKUs[sku] {
+			diff.AddedSKUs = append(diff.AddedSKUs, sku)
+		}
+	}
+	for sku := range oldSKUs {
+		if !newSKUs[sku] {
+			diff.RemovedSKUs = append(diff.RemovedSKUs, sku)
+		}
+	}
+	sort.Strings(diff.AddedSKUs)
+	sort.Strings(diff.RemovedSKUs)
+
+	for key, oldPrice := range oldPrices {
+		if _, ok := newPrices[key]; !ok && newSKUs[key.sku] {
+			diff.RemovedRates = append(diff.RemovedRates, oldPrice)
+		}
+	}
+	for key, newPrice := range newPrices {
+		oldPrice, ok := oldPrices[key]
+		if !ok {
+			if oldSKUs[key.sku] {
+				diff.AddedRates = append(diff.AddedRates, newPrice)
+			}
+			continue
+		}
+		if oldPrice.Price == newPrice.Price {
+			continue
+		}
+		change := PriceChange{
+			SKU:           key.sku,
+			TermType:      newPrice.TermType,
+			OfferTermCode: key.offerTermCode,
+			RateCode:      key.rateCode,
+			Currency:      key.currency,
+			OldPrice:      oldPrice.Price,
+			NewPrice:      newPrice.Price,
+		}
+		if oldPrice.Price != 0 {
+			change.PercentChange = (newPrice.Price - oldPrice.Price) / oldPrice.Price * 100
+		}
+		diff.PriceChanges = append(diff.PriceChanges, change)
+	}
+	sort.Slice(diff.PriceChanges, func(i, j int) bool {
+		a, b := diff.PriceChanges[i], diff.PriceChanges[j]
+		if a.SKU != b.SKU {
+			return a.SKU < b.SKU
+		}
+		if a.RateCode != b.RateCode {
+			return a.RateCode < b.RateCode
+		}
+		return a.Currency < b.Currency
+	})
+	sortRates(diff.AddedRates)
+	sortRates(diff.RemovedRates)
+	return diff
+}
+
+// sortRates orders rates by SKU, rate code and currency
+func sortRates(rates []Rate) {
+	sort.Slice(rates, func(i, j int) bool {
+		a, b := rates[i], rates[j]
+		if a.SKU != b.SKU {
+			return a.SKU < b.SKU
+		}
+		if a.RateCode != b.RateCode {
+			return a.RateCode < b.RateCode
+		}
+		return a.Currency < b.Currency
+	})
+}
+
+func indexPrices(docs []PricingDocument) (skus map[string]bool, prices map[priceKey]Rate) {
+	skus = make(map[string]bool)
+	prices = make(map[priceKey]Rate)
+	for _, doc := range docs {
+		sku := doc.Product.SKU
+		skus[sku] = true
+		walkRates(doc, func(r Rate) {
+			prices[priceKey{sku: sku, offerTermCode: r.OfferTermCode, rateCode: r.RateCode, currency: r.Currency}] = r
+		})
+	}
+	return skus, prices
+}
+
+// latestVersion returns the most recent version, and its publication date, of the documents
+func latestVersion(docs []PricingDocument) (version, publicationDate string) {
+	for _, doc := range docs {
+		if doc.Version > version {
+			version = doc.Version
+			publicationDate = doc.PublicationDate
+		}
+	}
+	return version, publicationDate
+}
@@ -0,0 +1,61 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestDiffPricingDocuments(t *testing.T) {
+	oldDocs := []PricingDocument{
+		getMockDocumentWithOnDemandPrice(t, "SKUA", 0.2),
+		getMockDocumentWithOnDemandPrice(t, "SKUB", 0.1),
+	}
+	newDocs := []PricingDocument{
+		getMockDocumentWithOnDemandPrice(t, "SKUA", 0.15),
+		getMockDocumentWithOnDemandPrice(t, "SKUC", 0.1),
+	}
+	newDocs[0].Version = "20180901000000"
+
+	diff := DiffPricingDocuments(oldDocs, newDocs)
+	if diff.OldVersion != "20180727015836" || diff.NewVersion != "20180901000000" {
+		t.Errorf("unexpected versions: %s %s", diff.OldVersion, diff.NewVersion)
+	}
+	if len(diff.AddedSKUs) != 1 || diff.AddedSKUs[0] != "SKUC" {
+		t.Errorf("unexpected added skus: %v", diff.AddedSKUs)
+	}
+	if len(diff.RemovedSKUs) != 1 || diff.RemovedSKUs[0] != "SKUB" {
+		t.Errorf("unexpected removed skus: %v", diff.RemovedSKUs)
+	}
+	if len(diff.PriceChanges) != 1 {
+		t.Fatalf("expected 1 price change but got: %+v", diff.PriceChanges)
+	}
+	change := diff.PriceChanges[0]
+	if change.SKU != "SKUA" || change.RateCode != "SKUA.JRTCKXETXF.6YS6EN2CT7" || change.TermType != "OnDemand" {
+		t.Errorf("unexpected price change: %+v", change)
+	}
+	if change.PercentChange > -24.99 || change.PercentChange < -25.01 {
+		t.Errorf("expected -25%% change but got: %f", change.PercentChange)
+	}
+}
+
+func TestDiffAddedAndRemovedRates(t *testing.T) {
+	oldDoc := getMockPricingDocuments(t)[0]
+	newDoc := getMockPricingDocuments(t)[0]
+	// the reserved offer is withdrawn and the on demand term gains a CNY price
+	newDoc.Terms.Reserved = nil
+	onDemand := newDoc.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"]
+	for _, pd := range onDemand.PriceDimensions {
+		for rateCode, item := range pd {
+			item.PricePerUnit = append(item.PricePerUnit, PricePerUnit{"CNY": 0.8})
+			pd[rateCode] = item
+		}
+	}
+
+	diff := DiffPricingDocuments([]PricingDocument{oldDoc}, []PricingDocument{newDoc})
+	if len(diff.AddedSKUs)+len(diff.RemovedSKUs)+len(diff.PriceChanges) != 0 {
+		t.Errorf("expected only rate differences but got: %+v", diff)
+	}
+	if len(diff.RemovedRates) != 1 || diff.RemovedRates[0].TermType != TermTypeReserved || diff.RemovedRates[0].Price != 0.0756 {
+		t.Errorf("unexpected removed rates: %+v", diff.RemovedRates)
+	}
+	if len(diff.AddedRates) != 1 || diff.AddedRates[0].Currency != "CNY" || diff.AddedRates[0].Price != 0.8 {
+		t.Errorf("unexpected added rates: %+v", diff.AddedRates)
+	}
+}
@@ -0,0 +1,190 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UnknownFieldError is returned when a price list item contains a field the library does not know,
+// which usually means AWS have changed the schema of the price list
+type UnknownFieldError struct {
+	SKU   string
+	Path  string
+	Value interface{}
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unexpected field %s%s with value: %+v", e.Path, skuSuffix(e.SKU), e.Value)
+}
+
+// TypeMismatchError is returned when a field of a price list item has a type other than the one expected
+type TypeMismatchError struct {
+	SKU      string
+	Path     string
+	Expected string
+	Value    interface{}
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("unexpected type %T for %s%s, expected %s: %+v", e.Value, e.Path, skuSuffix(e.SKU), e.Expected, e.Value)
+}
+
+// PriceParseError is returned when a price cannot be parsed as a number
+type PriceParseError struct {
+	SKU   string
+	Path  string
+	Value string
+	Err   error
+}
+
+func (e *PriceParseError) Error() string {
+	return fmt.Sprintf("failed to parse price %q at %s%s: %+v", e.Value, e.Path, skuSuffix(e.SKU), e.Err)
+}
+
+// Unwrap returns the underlying parse error
+func (e *PriceParseError) Unwrap() error {
+	return e.Err
+}
+
+// TimestampParseError is returned when a date that must be a timestamp cannot be parsed as one
+type TimestampParseError struct {
+	SKU   string
+	Path  string
+	Value string
+	Err   error
+}
+
+func (e *TimestampParseError) Error() string {
+	return fmt.Sprintf("failed to parse timestamp %q at %s%s: %+v", e.Value, e.Path, skuSuffix(e.SKU), e.Err)
+}
+
+// Unwrap returns the underlying parse error
+func (e *TimestampParseError) Unwrap() error {
+	return e.Err
+}
+
+func skuSuffix(sku string) string {
+	if sku == "" {
+		return ""
+	}
+	return " of sku " + sku
+}
+
+// MultiError holds every problem found in a price list item
+// Errors are ordered by the path of the field they relate to, so the same input always gives the same error
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors so that they can be inspected with errors.Is and errors.As
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// errorList collects the problems found while processing a price list item
+type errorList []error
+
+func (l *errorList) add(err error) {
+	if err != nil {
+		*l = append(*l, err)
+	}
+}
+
+// err returns the collected errors, with the SKU of the item set, as a MultiError or nil if there are none
+func (l errorList) err(sku string) error {
+	if len(l) == 0 {
+		return nil
+	}
+	for _, err := range l {
+		switch e := err.(type) {
+		case *UnknownFieldError:
+			e.SKU = sku
+		case *TypeMismatchError:
+			e.SKU = sku
+		case *PriceParseError:
+			e.SKU = sku
+		case *TimestampParseError:
+			e.SKU = sku
+		case *ValidationError:
+			e.SKU = sku
+		}
+	}
+	return &MultiError{Errors: l}
+}
+
+// asMap checks the value at path is a JSON object
+func (l *errorList) asMap(path string, v interface{}) (map[string]interface{}, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		l.add(&TypeMismatchError{Path: path, Expected: "object", Value: v})
+	}
+	return m, ok
+}
+
+// asString checks the value at path is a JSON string
+func (l *errorList) asString(path string, v interface{}) string {
+	str, ok := v.(string)
+	if !ok {
+		l.add(&TypeMismatchError{Path: path, Expected: "string", Value: v})
+	}
+	return str
+}
+
+// asTimestamp checks the value at path is a JSON string and returns it with the time it holds
+// A string that is not an RFC 3339 timestamp is kept with a zero time and left for Validate to report
+func (l *errorList) asTimestamp(path string, v interface{}) (string, time.Time) {
+	str := l.asString(path, v)
+	return str, parseTimestamp(str)
+}
+
+// timestamp parses the value at path as an RFC 3339 timestamp, for dates that cannot be left for Validate
+func (l *errorList) timestamp(path, value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		l.add(&TimestampParseError{Path: path, Value: value, Err: err})
+	}
+	return t
+}
+
+// parseTimestamp returns the time of an RFC 3339 timestamp or the zero time if it is not one
+func parseTimestamp(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// sortedKeys returns the keys of a JSON object in order so that it is processed deterministically
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path string, elems ...string) string {
+	for _, elem := range elems {
+		if path == "" {
+			path = elem
+			continue
+		}
+		path += "." + elem
+	}
+	return path
+}
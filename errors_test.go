@@ -0,0 +1,171 @@
+package awsPricingTyper
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/pricing"
+)
+
+// get output containing a single good mock item that can be modified to introduce errors
+func getMockOutputItem() (pricing.GetProductsOutput, aws.JSONValue) {
+	resetMockFailures()
+	item := aws.JSONValue(getMockPriceList(getMockProduct(), getMockTerms()))
+	return pricing.GetProductsOutput{PriceList: []aws.JSONValue{item}}, item
+}
+
+func TestUnknownFieldError(t *testing.T) {
+	output, item := getMockOutputItem()
+	item["product"].(map[string]interface{})["productAttributes"].(map[string]interface{})["badAttr"] = "a value"
+
+	_, err := GetTypedPricingData(output)
+	var unknownField *UnknownFieldError
+	if !errors.As(err, &unknownField) {
+		t.Fatalf("expected unknown field error but got: %+v", err)
+	}
+	if unknownField.SKU != "7X4K64YA59VZZAC3" || unknownField.Path != "product.productAttributes.badAttr" || unknownField.Value != "a value" {
+		t.Errorf("unexpected error detail: %+v", unknownField)
+	}
+}
+
+func TestUnknownTermFieldErrors(t *testing.T) {
+	output, item := getMockOutputItem()
+	terms := item["terms"].(map[string]interface{})
+	terms["Spot"] = map[string]interface{}{}
+	onDemand := terms["OnDemand"].(map[string]interface{})["7X4K64YA59VZZAC3.JRTCKXETXF"].(map[string]interface{})
+	onDemand["badTermField"] = "a value"
+	reserved := terms["Reserved"].(map[string]interface{})["7X4K64YA59VZZAC3.4NA7Y494T4"].(map[string]interface{})
+	reserved["badTermField"] = "a value"
+	reserved["termAttributes"].(map[string]interface{})["BadAttribute"] = "a value"
+
+	_, err := GetTypedPricingData(output)
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("expected multi error but got: %+v", err)
+	}
+	expectedPaths := []string{
+		"terms.OnDemand.7X4K64YA59VZZAC3.JRTCKXETXF.badTermField",
+		"terms.Reserved.7X4K64YA59VZZAC3.4NA7Y494T4.badTermField",
+		"terms.Reserved.7X4K64YA59VZZAC3.4NA7Y494T4.termAttributes.BadAttribute",
+		"terms.Spot",
+	}
+	if len(multi.Errors) != len(expectedPaths) {
+		t.Fatalf("expected %d errors but got: %+v", len(expectedPaths), multi.Errors)
+	}
+	for i, expected := range expectedPaths {
+		unknownField, ok := multi.Errors[i].(*UnknownFieldError)
+		if !ok || unknownField.Path != expected {
+			t.Errorf("expected unknown field error at %s but got: %+v", expected, multi.Errors[i])
+		}
+	}
+}
+
+func TestTypeMismatchError(t *testing.T) {
+	output, item := getMockOutputItem()
+	item["terms"].(map[string]interface{})["Reserved"] = "badTypeValue"
+
+	_, err := GetTypedPricingData(output)
+	var typeMismatch *TypeMismatchError
+	if !errors.As(err, &typeMismatch) {
+		t.Fatalf("expected type mismatch error but got: %+v", err)
+	}
+	if typeMismatch.SKU != "7X4K64YA59VZZAC3" || typeMismatch.Path != "terms.Reserved" || typeMismatch.Expected != "object" {
+		t.Errorf("unexpected error detail: %+v", typeMismatch)
+	}
+}
+
+func TestPriceParseError(t *testing.T) {
+	output, item := getMockOutputItem()
+	rateCode := "7X4K64YA59VZZAC3.4NA7Y494T4.6YS6EN2CT7"
+	reserved := item["terms"].(map[string]interface{})["Reserved"].(map[string]interface{})
+	dimension := reserved["7X4K64YA59VZZAC3.4NA7Y494T4"].(map[string]interface{})["priceDimensions"].(map[string]interface{})[rateCode]
+	dimension.(map[string]interface{})["pricePerUnit"] = map[string]interface{}{"USD": "0.07.56"}
+
+	_, err := GetTypedPricingData(output)
+	var priceParse *PriceParseError
+	if !errors.As(err, &priceParse) {
+		t.Fatalf("expected price parse error but got: %+v", err)
+	}
+	expectedPath := "terms.Reserved.7X4K64YA59VZZAC3.4NA7Y494T4.priceDimensions." + rateCode + ".pricePerUnit.USD"
+	if priceParse.SKU != "7X4K64YA59VZZAC3" || priceParse.Path != expectedPath || priceParse.Value != "0.07.56" {
+		t.Errorf("unexpected error detail: %+v", priceParse)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected error to wrap the parse error: %+v", err)
+	}
+}
+
+func TestUnparseableTimestamp(t *testing.T) {
+	output, item := getMockOutputItem()
+	item["publicationDate"] = "27/07/2018"
+	onDemand := item["terms"].(map[string]interface{})["OnDemand"].(map[string]interface{})
+	onDemand["7X4K64YA59VZZAC3.JRTCKXETXF"].(map[string]interface{})["effectiveDate"] = "2018-07-01"
+
+	docs, err := GetTypedPricingData(output)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	doc := docs[0]
+	term := doc.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"]
+	if doc.PublicationDate != "27/07/2018" || !doc.PublicationTime.IsZero() {
+		t.Errorf("expected the raw publication date with a zero time but got: %q %v", doc.PublicationDate, doc.PublicationTime)
+	}
+	if term.EffectiveDate != "2018-07-01" || !term.EffectiveTime.IsZero() {
+		t.Errorf("expected the raw effective date with a zero time but got: %q %v", term.EffectiveDate, term.EffectiveTime)
+	}
+
+	var multi *MultiError
+	if !errors.As(Validate(doc), &multi) {
+		t.Fatalf("expected validation errors")
+	}
+	reported := make(map[string]bool)
+	for _, e := range multi.Errors {
+		reported[e.(*ValidationError).Path] = true
+	}
+	for _, path := range []string{"publicationDate", "terms.OnDemand.7X4K64YA59VZZAC3.JRTCKXETXF.effectiveDate"} {
+		if !reported[path] {
+			t.Errorf("expected %s to be reported but got: %+v", path, multi)
+		}
+	}
+}
+
+func TestMultiErrorIsExhaustiveAndDeterministic(t *testing.T) {
+	var first string
+	for i := 0; i < 20; i++ {
+		output, item := getMockOutputItem()
+		item["product"].(map[string]interface{})["badItem"] = "Bad Value"
+		item["product"].(map[string]interface{})["productAttributes"].(map[string]interface{})["badAttr"] = "a value"
+		item["product"].(map[string]interface{})["productAttributes"].(map[string]interface{})["vcpu"] = 2.0
+		item["terms"].(map[string]interface{})["Reserved"] = "badTypeValue"
+		item["invalid"] = "invalid"
+
+		_, err := GetTypedPricingData(output)
+		var multi *MultiError
+		if !errors.As(err, &multi) {
+			t.Fatalf("expected multi error but got: %+v", err)
+		}
+		if len(multi.Errors) != 5 {
+			t.Fatalf("expected 5 errors but got: %+v", multi.Errors)
+		}
+		paths := []string{
+			multi.Errors[0].(*UnknownFieldError).Path,
+			multi.Errors[1].(*UnknownFieldError).Path,
+			multi.Errors[2].(*UnknownFieldError).Path,
+			multi.Errors[3].(*TypeMismatchError).Path,
+			multi.Errors[4].(*TypeMismatchError).Path,
+		}
+		expected := []string{"invalid", "product.badItem", "product.productAttributes.badAttr", "product.productAttributes.vcpu", "terms.Reserved"}
+		for j := range expected {
+			if paths[j] != expected[j] {
+				t.Errorf("expected error %d to have path %s but got: %s", j, expected[j], paths[j])
+			}
+		}
+		if i == 0 {
+			first = err.Error()
+		} else if err.Error() != first {
+			t.Fatalf("expected the same error for the same input but got:\n%s\n%s", first, err.Error())
+		}
+	}
+}
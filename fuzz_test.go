@@ -0,0 +1,84 @@
+package awsPricingTyper
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/pricing"
+)
+
+// typeAllDocuments types the item keeping every document and exercises the accessors of the results
+func typeAllDocuments(item aws.JSONValue) error {
+	output := pricing.GetProductsOutput{PriceList: []aws.JSONValue{item}}
+	options := Options{KeepWithoutOnDemand: true, KeepZeroPriced: true, KeepMultipleOnDemand: true}
+	docs, _, err := GetTypedPricingDataWithOptions(output, options)
+	for _, doc := range docs {
+		doc.Rates()
+		doc.ReservedOffers(CurrencyUSD)
+		_, _ = doc.OnDemandHourly(CurrencyUSD)
+	}
+	return err
+}
+
+func FuzzGetTypedPricingData(f *testing.F) {
+	resetMockFailures()
+	seed, err := json.Marshal(getMockPriceList(getMockProduct(), getMockTerms()))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add([]byte(`{"product": {"sku": 1}, "terms": {"OnDemand": {"A.B": {"priceDimensions": {"A.B.C": {"pricePerUnit": {"USD": "x"}}}}}}}`))
+	f.Add([]byte(`{"terms": {"Reserved": {"A.B": {"termAttributes": {"PurchaseOption": []}}}}}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var item aws.JSONValue
+		if json.Unmarshal(data, &item) != nil {
+			return
+		}
+		_ = typeAllDocuments(item)
+	})
+}
+
+func FuzzReadGetProductsOutput(f *testing.F) {
+	f.Add(`{"FormatVersion": "aws_v1", "PriceList": ["{\"serviceCode\": \"AmazonEC2\"}"]}`)
+	f.Add(`{"PriceList": [{"product": {}}, "[]", null]}`)
+	f.Fuzz(func(t *testing.T, data string) {
+		output, err := ReadGetProductsOutput(strings.NewReader(data))
+		if err != nil {
+			return
+		}
+		for _, item := range output.PriceList {
+			_ = typeAllDocuments(item)
+		}
+	})
+}
+
+// replacing any single value of a good document with a value of the wrong type must return an error, not panic
+func TestTyperWithSubstitutedTypes(t *testing.T) {
+	substitutes := []interface{}{nil, 1.5, "string", []interface{}{"a"}, map[string]interface{}{"a": "b"}}
+	for _, substitute := range substitutes {
+		resetMockFailures()
+		item := aws.JSONValue(getMockPriceList(getMockProduct(), getMockTerms()))
+		substituteValues(item, substitute, func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("panic with substitute %#v: %+v", substitute, r)
+				}
+			}()
+			_ = typeAllDocuments(item)
+		})
+	}
+}
+
+// substituteValues replaces each value in the tree with substitute in turn, calling fn, and then restores it
+func substituteValues(m map[string]interface{}, substitute interface{}, fn func()) {
+	for k, v := range m {
+		m[k] = substitute
+		fn()
+		m[k] = v
+		if child, ok := v.(map[string]interface{}); ok {
+			substituteValues(child, substitute, fn)
+		}
+	}
+}
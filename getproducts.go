@@ -0,0 +1,57 @@
+package awsPricingTyper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/pricing"
+)
+
+// getProductsJSON is the layout of GetProducts output saved as JSON
+// The AWS CLI emits each PriceList item as a JSON encoded string whereas
+// marshalled SDK output contains objects, so items are decoded individually
+type getProductsJSON struct {
+	FormatVersion *string
+	NextToken     *string
+	PriceList     []json.RawMessage
+}
+
+// ReadGetProductsOutput reads GetProducts output saved as JSON, e.g. by `aws pricing get-products`,
+// and returns it ready to be passed to GetTypedPricingData
+// The reader may contain several concatenated outputs, such as one per page, which are merged
+func ReadGetProductsOutput(r io.Reader) (output pricing.GetProductsOutput, err error) {
+	dec := json.NewDecoder(r)
+	for page := 1; ; page++ {
+		var raw getProductsJSON
+		err = dec.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return output, fmt.Errorf("failed to decode GetProducts output %d: %+v", page, err)
+		}
+		if raw.FormatVersion != nil {
+			output.FormatVersion = raw.FormatVersion
+		}
+		for i, item := range raw.PriceList {
+			var value aws.JSONValue
+			value, err = decodePriceListItem(item)
+			if err != nil {
+				return output, fmt.Errorf("failed to decode price list item %d of output %d: %+v", i, page, err)
+			}
+			output.PriceList = append(output.PriceList, value)
+		}
+	}
+	return output, nil
+}
+
+func decodePriceListItem(item json.RawMessage) (value aws.JSONValue, err error) {
+	var encoded string
+	if json.Unmarshal(item, &encoded) == nil {
+		item = json.RawMessage(encoded)
+	}
+	err = json.Unmarshal(item, &value)
+	return value, err
+}
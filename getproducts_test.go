@@ -0,0 +1,31 @@
+package awsPricingTyper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadGetProductsOutput(t *testing.T) {
+	// the AWS CLI encodes items as strings, SDK output as objects
+	input := `{"FormatVersion": "aws_v1", "PriceList": ["{\"serviceCode\": \"AmazonEC2\"}"]}
+{"FormatVersion": "aws_v1", "PriceList": [{"serviceCode": "AmazonS3"}]}`
+	output, err := ReadGetProductsOutput(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if len(output.PriceList) != 2 {
+		t.Fatalf("expected 2 price list items but got: %d", len(output.PriceList))
+	}
+	if output.PriceList[0]["serviceCode"] != "AmazonEC2" || output.PriceList[1]["serviceCode"] != "AmazonS3" {
+		t.Errorf("unexpected price list: %+v", output.PriceList)
+	}
+	if output.FormatVersion == nil || *output.FormatVersion != "aws_v1" {
+		t.Errorf("unexpected format version: %v", output.FormatVersion)
+	}
+}
+
+func TestReadGetProductsOutputWithInvalidItem(t *testing.T) {
+	if _, err := ReadGetProductsOutput(strings.NewReader(`{"PriceList": ["not json"]}`)); err == nil {
+		t.Error("expected invalid price list item error")
+	}
+}
@@ -0,0 +1,125 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PriceHistory retains the documents of each SKU from multiple publications of a price list so that
+// the prices in effect at a past time can be found, e.g. to re-cost usage after a price reduction
+// It is safe for concurrent use
+type PriceHistory struct {
+	mu    sync.RWMutex
+	bySKU map[string][]PricingDocument
+}
+
+// NewPriceHistory returns an empty PriceHistory
+func NewPriceHistory() *PriceHistory {
+	return &PriceHistory{bySKU: make(map[string][]PricingDocument)}
+}
+
+// Add adds documents to the history, replacing any of the same SKU and version already added
+func (h *PriceHistory) Add(docs ...PricingDocument) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, doc := range docs {
+		sku := doc.Product.SKU
+		versions := h.bySKU[sku]
+		replaced := false
+		for i := range versions {
+			if versions[i].Version == doc.Version {
+				versions[i] = doc
+				replaced = true
+			}
+		}
+		if !replaced {
+			versions = append(versions, doc)
+		}
+		sort.SliceStable(versions, func(i, j int) bool {
+			if !versions[i].PublicationTime.Equal(versions[j].PublicationTime) {
+				return versions[i].PublicationTime.Before(versions[j].PublicationTime)
+			}
+			return versions[i].Version < versions[j].Version
+		})
+		h.bySKU[sku] = versions
+	}
+}
+
+// Versions returns the documents of the SKU ordered from the earliest to the latest publication
+func (h *PriceHistory) Versions(sku string) []PricingDocument {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	versions := make([]PricingDocument, len(h.bySKU[sku]))
+	copy(versions, h.bySKU[sku])
+	return versions
+}
+
+// DocumentAt returns the SKU's document as it was priced at the time
+// The product and the set of terms are those of the latest publication that is not after the time, so
+// terms withdrawn by that publication are not returned, and each term is the version from that or an
+// earlier publication with the latest effective time that is not after the time
+// Terms without an effective time are never in effect
+func (h *PriceHistory) DocumentAt(sku string, t time.Time) (doc PricingDocument, err error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	versions := h.bySKU[sku]
+	if len(versions) == 0 {
+		return doc, fmt.Errorf("no history for sku: %s", sku)
+	}
+	published := sort.Search(len(versions), func(i int) bool {
+		return versions[i].PublicationTime.After(t)
+	})
+	if published == 0 {
+		return doc, fmt.Errorf("no publication of sku: %s at or before %s", sku, t.Format(time.RFC3339))
+	}
+	versions = versions[:published]
+	doc = versions[len(versions)-1]
+	doc.Terms.OnDemand = make(map[string]OnDemandTerm)
+	doc.Terms.Reserved = make(map[string]ReservedTerm)
+	latest := versions[len(versions)-1].Terms
+	// later publications replace terms of the same effective time
+	for _, version := range versions {
+		for code, term := range version.Terms.OnDemand {
+			if _, current := latest.OnDemand[code]; !current {
+				continue
+			}
+			existing, ok := doc.Terms.OnDemand[code]
+			if inEffect(term.EffectiveTime, t) && (!ok || !term.EffectiveTime.Before(existing.EffectiveTime)) {
+				doc.Terms.OnDemand[code] = term
+			}
+		}
+		for code, term := range version.Terms.Reserved {
+			if _, current := latest.Reserved[code]; !current {
+				continue
+			}
+			existing, ok := doc.Terms.Reserved[code]
+			if inEffect(term.EffectiveTime, t) && (!ok || !term.EffectiveTime.Before(existing.EffectiveTime)) {
+				doc.Terms.Reserved[code] = term
+			}
+		}
+	}
+	if len(doc.Terms.OnDemand) == 0 && len(doc.Terms.Reserved) == 0 {
+		return doc, fmt.Errorf("no terms of sku: %s in effect at %s", sku, t.Format(time.RFC3339))
+	}
+	return doc, nil
+}
+
+// RatesAt returns the Rates of the SKU in effect at the time
+func (h *PriceHistory) RatesAt(sku string, t time.Time) ([]Rate, error) {
+	doc, err := h.DocumentAt(sku, t)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Rates(), nil
+}
+
+// OnDemandHourlyAt returns the hourly on-demand price of the SKU in effect at the time in the currency
+func (h *PriceHistory) OnDemandHourlyAt(sku, currency string, t time.Time) (float64, error) {
+	doc, err := h.DocumentAt(sku, t)
+	if err != nil {
+		return 0, err
+	}
+	return doc.OnDemandHourly(currency)
+}
@@ -0,0 +1,68 @@
+package awsPricingTyper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriceHistory(t *testing.T) {
+	july := getMockPricingDocuments(t)[0]
+	// a price reduction published on the 20th of August, effective from the 1st of September,
+	// that also withdraws the reserved term
+	september := getMockPricingDocuments(t)[0]
+	september.Version = "20180820000000"
+	september.PublicationTime = time.Date(2018, 8, 20, 0, 0, 0, 0, time.UTC)
+	onDemand := september.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"]
+	onDemand.EffectiveTime = time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	onDemand.PriceDimensions = []PriceDimension{{"7X4K64YA59VZZAC3.JRTCKXETXF.6YS6EN2CT7": {Unit: "Hrs", PricePerUnit: []PricePerUnit{{"USD": 0.1}}}}}
+	september.Terms.OnDemand = map[string]OnDemandTerm{"7X4K64YA59VZZAC3.JRTCKXETXF": onDemand}
+	september.Terms.Reserved = nil
+
+	history := NewPriceHistory()
+	history.Add(september, july)
+	history.Add(july)
+	if versions := history.Versions("7X4K64YA59VZZAC3"); len(versions) != 2 || versions[0].Version != july.Version {
+		t.Fatalf("unexpected versions: %+v", versions)
+	}
+
+	tests := []struct {
+		at       time.Time
+		expected float64
+	}{
+		{time.Date(2018, 8, 15, 0, 0, 0, 0, time.UTC), 0.111},
+		// published but not yet effective so the July price applies
+		{time.Date(2018, 8, 25, 0, 0, 0, 0, time.UTC), 0.111},
+		{time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC), 0.1},
+		{time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), 0.1},
+	}
+	for _, test := range tests {
+		price, err := history.OnDemandHourlyAt("7X4K64YA59VZZAC3", CurrencyUSD, test.at)
+		if err != nil || price != test.expected {
+			t.Errorf("expected %f at %s but got: %f %+v", test.expected, test.at, price, err)
+		}
+	}
+
+	rates, err := history.RatesAt("7X4K64YA59VZZAC3", time.Date(2018, 8, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil || len(rates) != 2 {
+		t.Errorf("expected the on demand and reserved rates but got: %+v %+v", rates, err)
+	}
+	// the reserved term is withdrawn by the August publication
+	for _, at := range []time.Time{time.Date(2018, 8, 25, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)} {
+		rates, err = history.RatesAt("7X4K64YA59VZZAC3", at)
+		if err != nil || len(rates) != 1 || rates[0].TermType != TermTypeOnDemand {
+			t.Errorf("expected only the on demand rate at %s but got: %+v %+v", at, rates, err)
+		}
+	}
+	if _, err = history.DocumentAt("7X4K64YA59VZZAC3", time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("expected error before the first publication")
+	}
+	// terms whose effective dates could not be parsed are never in effect
+	unparsed := getMockDocumentWithOnDemandPrice(t, "UNPARSED", 0.1)
+	history.Add(unparsed)
+	if _, err = history.DocumentAt("UNPARSED", time.Now()); err == nil {
+		t.Error("expected error for a term without an effective time")
+	}
+	if _, err = history.DocumentAt("MISSING", time.Now()); err == nil {
+		t.Error("expected error for missing sku")
+	}
+}
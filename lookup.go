@@ -0,0 +1,151 @@
+package awsPricingTyper
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InstanceSpec identifies the SKU of an instance
+// Empty fields match any document so, for a single match, set enough fields to tell apart the
+// SKUs of an instance type in a location, e.g. CapacityStatus "Used" for running instances
+type InstanceSpec struct {
+	InstanceType    string
+	Location        string
+	OperatingSystem string
+	Tenancy         string
+	LicenseModel    string
+	PreInstalledSw  string
+	CapacityStatus  string
+	Operation       string
+}
+
+// instanceSpecAttributes are the attributes compared by lookups in the order they are reported
+var instanceSpecAttributes = []struct {
+	name  string
+	spec  func(s InstanceSpec) string
+	value func(doc PricingDocument) string
+}{
+	{"instanceType", func(s InstanceSpec) string { return s.InstanceType }, func(doc PricingDocument) string { return doc.Product.Attributes.InstanceType }},
+	{"location", func(s InstanceSpec) string { return s.Location }, func(doc PricingDocument) string { return doc.Product.Attributes.Location }},
+	{"operatingSystem", func(s InstanceSpec) string { return s.OperatingSystem }, func(doc PricingDocument) string { return doc.Product.Attributes.OperatingSystem }},
+	{"tenancy", func(s InstanceSpec) string { return s.Tenancy }, func(doc PricingDocument) string { return doc.Product.Attributes.Tenancy }},
+	{"licenseModel", func(s InstanceSpec) string { return s.LicenseModel }, func(doc PricingDocument) string { return doc.Product.Attributes.LicenseModel }},
+	{"preInstalledSw", func(s InstanceSpec) string { return s.PreInstalledSw }, func(doc PricingDocument) string { return doc.Product.Attributes.PreInstalledSw }},
+	{"capacitystatus", func(s InstanceSpec) string { return s.CapacityStatus }, func(doc PricingDocument) string { return doc.Product.Attributes.CapacityStatus }},
+	{"operation", func(s InstanceSpec) string { return s.Operation }, func(doc PricingDocument) string { return doc.Product.Attributes.Operation }},
+}
+
+// DistinguishingAttribute is an attribute whose values differ between the documents matching a spec
+type DistinguishingAttribute struct {
+	Name   string
+	Values []string
+}
+
+// AmbiguousSpecError is returned by a lookup when more than one document matches the spec
+type AmbiguousSpecError struct {
+	Spec InstanceSpec
+	SKUs []string
+	// Attributes are those that could be added to the spec to select a single document
+	Attributes []DistinguishingAttribute
+}
+
+func (e *AmbiguousSpecError) Error() string {
+	attributes := make([]string, len(e.Attributes))
+	for i, attribute := range e.Attributes {
+		attributes[i] = fmt.Sprintf("%s (%s)", attribute.Name, strings.Join(attribute.Values, ", "))
+	}
+	return fmt.Sprintf("%d skus match %+v: %s, distinguished by: %s",
+		len(e.SKUs), e.Spec, strings.Join(e.SKUs, ", "), strings.Join(attributes, "; "))
+}
+
+// NoMatchError is returned by a lookup when no document matches the spec
+type NoMatchError struct {
+	Spec InstanceSpec
+}
+
+func (e *NoMatchError) Error() string {
+	return fmt.Sprintf("no sku matches %+v", e.Spec)
+}
+
+// Lookup returns the single document matching the spec
+func Lookup(docs []PricingDocument, spec InstanceSpec) (PricingDocument, error) {
+	var matches []PricingDocument
+	for _, doc := range docs {
+		if matchesInstanceSpec(doc, spec) {
+			matches = append(matches, doc)
+		}
+	}
+	return resolveInstanceSpec(matches, spec)
+}
+
+// Lookup returns the single document in the catalog matching the spec
+func (c *Catalog) Lookup(spec InstanceSpec) (PricingDocument, error) {
+	var matches []PricingDocument
+	for _, doc := range c.Query(CatalogQuery{
+		InstanceType:    spec.InstanceType,
+		Location:        spec.Location,
+		OperatingSystem: spec.OperatingSystem,
+		Tenancy:         spec.Tenancy,
+		LicenseModel:    spec.LicenseModel,
+		PreInstalledSw:  spec.PreInstalledSw,
+	}) {
+		if matchesInstanceSpec(doc, spec) {
+			matches = append(matches, doc)
+		}
+	}
+	return resolveInstanceSpec(matches, spec)
+}
+
+// lookupRunning returns the single document for running instances matching the spec, i.e. with a
+// capacity status of Used, falling back to documents without a capacity status for price lists that
+// predate the attribute
+func (c *Catalog) lookupRunning(spec InstanceSpec) (PricingDocument, error) {
+	spec.CapacityStatus = "Used"
+	doc, err := c.Lookup(spec)
+	var noMatch *NoMatchError
+	if errors.As(err, &noMatch) {
+		spec.CapacityStatus = ""
+		doc, err = c.Lookup(spec)
+	}
+	return doc, err
+}
+
+func matchesInstanceSpec(doc PricingDocument, spec InstanceSpec) bool {
+	for _, attribute := range instanceSpecAttributes {
+		if want := attribute.spec(spec); want != "" && attribute.value(doc) != want {
+			return false
+		}
+	}
+	return true
+}
+
+func resolveInstanceSpec(matches []PricingDocument, spec InstanceSpec) (PricingDocument, error) {
+	switch len(matches) {
+	case 0:
+		return PricingDocument{}, &NoMatchError{Spec: spec}
+	case 1:
+		return matches[0], nil
+	}
+	ambiguous := &AmbiguousSpecError{Spec: spec}
+	for _, doc := range matches {
+		ambiguous.SKUs = append(ambiguous.SKUs, doc.Product.SKU)
+	}
+	sort.Strings(ambiguous.SKUs)
+	for _, attribute := range instanceSpecAttributes {
+		seen := make(map[string]bool)
+		var values []string
+		for _, doc := range matches {
+			if value := attribute.value(doc); !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+		if len(values) > 1 {
+			sort.Strings(values)
+			ambiguous.Attributes = append(ambiguous.Attributes, DistinguishingAttribute{Name: attribute.name, Values: values})
+		}
+	}
+	return PricingDocument{}, ambiguous
+}
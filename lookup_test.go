@@ -0,0 +1,77 @@
+package awsPricingTyper
+
+import (
+	"errors"
+	"testing"
+)
+
+func getMockLookupDocuments(t *testing.T) []PricingDocument {
+	docs := getMockCatalogDocuments(t)
+	allocated := docs[0]
+	allocated.Product.SKU = "m4.large.allocated"
+	allocated.Product.Attributes.CapacityStatus = "AllocatedCapacityReservation"
+	unused := docs[0]
+	unused.Product.SKU = "m4.large.unused"
+	unused.Product.Attributes.CapacityStatus = "UnusedCapacityReservation"
+	return append(docs, allocated, unused)
+}
+
+func TestLookup(t *testing.T) {
+	docs := getMockLookupDocuments(t)
+	catalog, err := NewCatalog(docs)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	lookups := map[string]func(spec InstanceSpec) (PricingDocument, error){
+		"docs": func(spec InstanceSpec) (PricingDocument, error) {
+			return Lookup(docs, spec)
+		},
+		"catalog": catalog.Lookup,
+	}
+	for name, lookup := range lookups {
+		doc, err := lookup(InstanceSpec{
+			InstanceType:    "m4.large",
+			Location:        "EU (Ireland)",
+			OperatingSystem: "Windows",
+			PreInstalledSw:  "SQL Std",
+		})
+		if err != nil || doc.Product.SKU != "m4.large.sql" {
+			t.Errorf("%s: expected sql sku but got: %s %+v", name, doc.Product.SKU, err)
+		}
+
+		doc, err = lookup(InstanceSpec{InstanceType: "m4.large", OperatingSystem: "Linux", CapacityStatus: "Used"})
+		if err != nil || doc.Product.SKU != "m4.large" {
+			t.Errorf("%s: expected used sku but got: %s %+v", name, doc.Product.SKU, err)
+		}
+
+		_, err = lookup(InstanceSpec{InstanceType: "m4.large", OperatingSystem: "Linux"})
+		var ambiguous *AmbiguousSpecError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("%s: expected ambiguous spec error but got: %+v", name, err)
+		}
+		if len(ambiguous.SKUs) != 3 || ambiguous.SKUs[0] != "m4.large" || ambiguous.SKUs[1] != "m4.large.allocated" {
+			t.Errorf("%s: unexpected skus: %v", name, ambiguous.SKUs)
+		}
+		if len(ambiguous.Attributes) != 1 || ambiguous.Attributes[0].Name != "capacitystatus" || len(ambiguous.Attributes[0].Values) != 3 {
+			t.Errorf("%s: unexpected distinguishing attributes: %+v", name, ambiguous.Attributes)
+		}
+
+		_, err = lookup(InstanceSpec{InstanceType: "m4.large", CapacityStatus: "Used"})
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("%s: expected ambiguous spec error but got: %+v", name, err)
+		}
+		var names []string
+		for _, attribute := range ambiguous.Attributes {
+			names = append(names, attribute.Name)
+		}
+		if len(names) != 3 || names[0] != "operatingSystem" || names[1] != "licenseModel" || names[2] != "preInstalledSw" {
+			t.Errorf("%s: unexpected distinguishing attributes: %v", name, names)
+		}
+
+		_, err = lookup(InstanceSpec{InstanceType: "m4.large", Location: "EU (Frankfurt)"})
+		var noMatch *NoMatchError
+		if !errors.As(err, &noMatch) {
+			t.Errorf("%s: expected no match error but got: %+v", name, err)
+		}
+	}
+}
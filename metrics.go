@@ -0,0 +1,44 @@
+package awsPricingTyper
+
+// ResourceMetrics are the on-demand hourly prices of a compute instance per unit of resource
+// Metrics are nil where the product does not have a numeric value for the resource,
+// e.g. an ECU of Variable or a NormalizationSizeFactor of NA
+type ResourceMetrics struct {
+	Currency              string
+	OnDemandHourly        float64
+	PerVCPUHour           *float64
+	PerGiBHour            *float64
+	PerECUHour            *float64
+	PerNormalizedUnitHour *float64
+}
+
+// ResourceMetrics returns the document's on-demand hourly price per vCPU, GiB of memory, ECU
+// and normalized unit in the requested currency
+func (doc PricingDocument) ResourceMetrics(currency string) (ResourceMetrics, error) {
+	return doc.ResourceMetricsIn(currency, nil)
+}
+
+// ResourceMetricsIn returns the document's ResourceMetrics in the requested currency, converting the
+// on-demand price using the exchange rates if the document is not priced in that currency
+func (doc PricingDocument) ResourceMetricsIn(currency string, rates ExchangeRateProvider) (metrics ResourceMetrics, err error) {
+	price, err := doc.OnDemandHourlyIn(currency, rates)
+	if err != nil {
+		return metrics, err
+	}
+	attrs := doc.Product.Attributes
+	metrics.Currency = currency
+	metrics.OnDemandHourly = price
+	metrics.PerVCPUHour = pricePerUnit(price, parseAttributeFloat(attrs.VCPU))
+	metrics.PerGiBHour = pricePerUnit(price, parseMemoryGiB(attrs.Memory))
+	metrics.PerECUHour = pricePerUnit(price, parseAttributeFloat(attrs.ECU))
+	metrics.PerNormalizedUnitHour = pricePerUnit(price, parseAttributeFloat(attrs.NormalizationSizeFactor))
+	return metrics, nil
+}
+
+func pricePerUnit(price float64, units *float64) *float64 {
+	if units == nil || *units <= 0 {
+		return nil
+	}
+	perUnit := price / *units
+	return &perUnit
+}
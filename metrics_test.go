@@ -0,0 +1,28 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestResourceMetrics(t *testing.T) {
+	doc := getMockInstanceDocument(t, "m4.large", "2", "8 GiB", 0.2)
+	doc.Product.Attributes.ECU = "Variable"
+	metrics, err := doc.ResourceMetrics("USD")
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if metrics.PerVCPUHour == nil || *metrics.PerVCPUHour != 0.1 {
+		t.Errorf("unexpected price per vcpu: %v", metrics.PerVCPUHour)
+	}
+	if metrics.PerGiBHour == nil || *metrics.PerGiBHour != 0.025 {
+		t.Errorf("unexpected price per GiB: %v", metrics.PerGiBHour)
+	}
+	if metrics.PerNormalizedUnitHour == nil || *metrics.PerNormalizedUnitHour != 0.05 {
+		t.Errorf("unexpected price per normalized unit: %v", metrics.PerNormalizedUnitHour)
+	}
+	if metrics.PerECUHour != nil {
+		t.Errorf("expected no price per ECU for Variable ECU but got: %f", *metrics.PerECUHour)
+	}
+
+	if _, err = doc.ResourceMetrics("CNY"); err == nil {
+		t.Error("expected missing currency error")
+	}
+}
@@ -0,0 +1,162 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NormalizationFactor returns the document's normalization size factor, e.g. 4 for a large instance
+func (doc PricingDocument) NormalizationFactor() (float64, error) {
+	factor := parseAttributeFloat(doc.Product.Attributes.NormalizationSizeFactor)
+	if factor == nil || *factor <= 0 {
+		return 0, fmt.Errorf("no normalization size factor for sku: %s, got: %q",
+			doc.Product.SKU, doc.Product.Attributes.NormalizationSizeFactor)
+	}
+	return *factor, nil
+}
+
+// NormalizedUnits returns the normalized units of a number of instances of the document
+func (doc PricingDocument) NormalizedUnits(count float64) (float64, error) {
+	factor, err := doc.NormalizationFactor()
+	if err != nil {
+		return 0, err
+	}
+	return count * factor, nil
+}
+
+// ConvertInstanceCount returns the number of instances of the to document that have the same normalized
+// units as count instances of the from document, e.g. 2 large instances are equivalent to 1 xlarge
+func ConvertInstanceCount(from, to PricingDocument, count float64) (float64, error) {
+	if !sameInstanceFamily(from, to) {
+		return 0, fmt.Errorf("instance types %s and %s are not in the same family",
+			from.Product.Attributes.InstanceType, to.Product.Attributes.InstanceType)
+	}
+	units, err := from.NormalizedUnits(count)
+	if err != nil {
+		return 0, err
+	}
+	factor, err := to.NormalizationFactor()
+	if err != nil {
+		return 0, err
+	}
+	return units / factor, nil
+}
+
+// InstanceUsage is a number of instances of a document running for an hour
+type InstanceUsage struct {
+	Document PricingDocument
+	Count    float64
+}
+
+// UsageCoverage is how much of an InstanceUsage a reservation covers
+type UsageCoverage struct {
+	InstanceUsage
+	// Eligible is false if size flexibility does not apply to the usage, e.g. it is of another
+	// instance family, location, operating system or tenancy than the reservation
+	Eligible               bool
+	NormalizedUnits        float64
+	CoveredNormalizedUnits float64
+	CoveredCount           float64
+	// UncoveredOnDemandCost is the hourly on-demand price of the instances not covered
+	UncoveredOnDemandCost float64
+}
+
+// SizeFlexibleCoverage is how an hour of a regional size-flexible reservation applies to instance usage
+type SizeFlexibleCoverage struct {
+	Offer                   ReservedOffer
+	Count                   float64
+	ReservedNormalizedUnits float64
+	UsedNormalizedUnits     float64
+	UnusedNormalizedUnits   float64
+	// ReservedCost is the effective hourly price of the reservation, including any upfront fee spread across the lease
+	ReservedCost float64
+	// OnDemandCost is the hourly on-demand price of the usage not covered by the reservation
+	OnDemandCost float64
+	Usage        []UsageCoverage
+}
+
+// SizeFlexibleReservation applies count instances of the reserved document's Reserved term with the
+// offer term code to the usage, as a regional Linux/UNIX reservation with shared tenancy applies to any
+// size in its instance family, smallest first, and returns the coverage and hourly costs in the currency
+func SizeFlexibleReservation(reserved PricingDocument, offerTermCode string, count float64, usage []InstanceUsage, currency string) (coverage SizeFlexibleCoverage, err error) {
+	attrs := reserved.Product.Attributes
+	if !isLinuxUnix(reserved) || attrs.Tenancy != TenancyShared {
+		return coverage, fmt.Errorf("reservations of %s (%s, %s) with %s tenancy are not size flexible",
+			attrs.OperatingSystem, attrs.PreInstalledSw, attrs.LicenseModel, attrs.Tenancy)
+	}
+	found := false
+	for _, offer := range reserved.ReservedOffers(currency) {
+		if offer.OfferTermCode == offerTermCode {
+			coverage.Offer = offer
+			found = true
+		}
+	}
+	if !found {
+		return coverage, fmt.Errorf("no reserved offer %s in %s for sku: %s", offerTermCode, currency, reserved.Product.SKU)
+	}
+	coverage.Count = count
+	coverage.ReservedCost = count * coverage.Offer.EffectiveHourly
+	if coverage.ReservedNormalizedUnits, err = reserved.NormalizedUnits(count); err != nil {
+		return coverage, err
+	}
+
+	coverage.Usage = make([]UsageCoverage, len(usage))
+	factors := make([]float64, len(usage))
+	for i, u := range usage {
+		coverage.Usage[i] = UsageCoverage{InstanceUsage: u}
+		factor, factorErr := u.Document.NormalizationFactor()
+		uAttrs := u.Document.Product.Attributes
+		coverage.Usage[i].Eligible = factorErr == nil && sameInstanceFamily(reserved, u.Document) &&
+			uAttrs.Location == attrs.Location && uAttrs.OperatingSystem == attrs.OperatingSystem &&
+			uAttrs.Tenancy == attrs.Tenancy && uAttrs.Operation == attrs.Operation &&
+			uAttrs.PreInstalledSw == attrs.PreInstalledSw && uAttrs.LicenseModel == attrs.LicenseModel
+		factors[i] = factor
+		coverage.Usage[i].NormalizedUnits = u.Count * factor
+	}
+	// the reservation applies to the smallest instances first
+	order := make([]int, len(usage))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return factors[order[a]] < factors[order[b]]
+	})
+	remaining := coverage.ReservedNormalizedUnits
+	for _, i := range order {
+		u := &coverage.Usage[i]
+		if u.Eligible && remaining > 0 {
+			u.CoveredNormalizedUnits = u.NormalizedUnits
+			if remaining < u.NormalizedUnits {
+				u.CoveredNormalizedUnits = remaining
+			}
+			u.CoveredCount = u.CoveredNormalizedUnits / factors[i]
+			remaining -= u.CoveredNormalizedUnits
+			coverage.UsedNormalizedUnits += u.CoveredNormalizedUnits
+		}
+		if uncovered := u.Count - u.CoveredCount; uncovered > 0 {
+			onDemand, onDemandErr := u.Document.OnDemandHourly(currency)
+			if onDemandErr != nil {
+				return coverage, onDemandErr
+			}
+			u.UncoveredOnDemandCost = uncovered * onDemand
+			coverage.OnDemandCost += u.UncoveredOnDemandCost
+		}
+	}
+	coverage.UnusedNormalizedUnits = remaining
+	return coverage, nil
+}
+
+// isLinuxUnix reports whether the document's operation is of the Linux/UNIX platform, i.e. Linux without
+// pre-installed software, and it has no license
+func isLinuxUnix(doc PricingDocument) bool {
+	platform, err := doc.Platform()
+	return err == nil && platform.Details == "Linux/UNIX" && doc.Product.Attributes.LicenseModel == "No License required"
+}
+
+// sameInstanceFamily reports whether the documents are instance types of the same family, e.g. m4
+func sameInstanceFamily(a, b PricingDocument) bool {
+	familyA, _, okA := strings.Cut(a.Product.Attributes.InstanceType, ".")
+	familyB, _, okB := strings.Cut(b.Product.Attributes.InstanceType, ".")
+	return okA && okB && familyA == familyB
+}
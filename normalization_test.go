@@ -0,0 +1,75 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestConvertInstanceCount(t *testing.T) {
+	large := getMockInstanceDocument(t, "m4.large", "2", "8 GiB", 0.111)
+	xlarge := getMockInstanceDocument(t, "m4.xlarge", "4", "16 GiB", 0.222)
+	xlarge.Product.Attributes.NormalizationSizeFactor = "8"
+	if count, err := ConvertInstanceCount(large, xlarge, 3); err != nil || count != 1.5 {
+		t.Errorf("expected 3 large to be 1.5 xlarge but got: %f %+v", count, err)
+	}
+	c4 := getMockInstanceDocument(t, "c4.large", "2", "3.75 GiB", 0.114)
+	if _, err := ConvertInstanceCount(large, c4, 1); err == nil {
+		t.Error("expected error converting between families")
+	}
+	xlarge.Product.Attributes.NormalizationSizeFactor = "NA"
+	if _, err := ConvertInstanceCount(large, xlarge, 1); err == nil {
+		t.Error("expected error for missing normalization factor")
+	}
+}
+
+func TestSizeFlexibleReservation(t *testing.T) {
+	reserved := getMockPricingDocuments(t)[0]
+	large := getMockInstanceDocument(t, "m4.large", "2", "8 GiB", 0.111)
+	xlarge := getMockInstanceDocument(t, "m4.xlarge", "4", "16 GiB", 0.222)
+	xlarge.Product.Attributes.NormalizationSizeFactor = "8"
+	c4 := getMockInstanceDocument(t, "c4.large", "2", "3.75 GiB", 0.114)
+	sql := getMockInstanceDocument(t, "m4.large.sql", "2", "8 GiB", 0.2)
+	sql.Product.Attributes.InstanceType = "m4.large"
+	sql.Product.Attributes.PreInstalledSw = "SQL Web"
+	sql.Product.Attributes.Operation = "RunInstances:0200"
+	usage := []InstanceUsage{{Document: xlarge, Count: 2}, {Document: c4, Count: 1}, {Document: large, Count: 1}, {Document: sql, Count: 1}}
+
+	// 3 large reserved are 12 normalized units, covering the large (4) and then one of the xlarges (8)
+	coverage, err := SizeFlexibleReservation(reserved, "4NA7Y494T4", 3, usage, CurrencyUSD)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if coverage.ReservedNormalizedUnits != 12 || coverage.UsedNormalizedUnits != 12 || coverage.UnusedNormalizedUnits != 0 {
+		t.Errorf("unexpected normalized units: %+v", coverage)
+	}
+	if !almostEqual(coverage.ReservedCost, 3*0.0756) || !almostEqual(coverage.OnDemandCost, 0.222+0.114+0.2) {
+		t.Errorf("unexpected costs: reserved %f on demand %f", coverage.ReservedCost, coverage.OnDemandCost)
+	}
+	xlargeCoverage, c4Coverage, largeCoverage, sqlCoverage := coverage.Usage[0], coverage.Usage[1], coverage.Usage[2], coverage.Usage[3]
+	if !xlargeCoverage.Eligible || xlargeCoverage.CoveredCount != 1 || xlargeCoverage.NormalizedUnits != 16 {
+		t.Errorf("unexpected xlarge coverage: %+v", xlargeCoverage)
+	}
+	if c4Coverage.Eligible || c4Coverage.CoveredCount != 0 || !almostEqual(c4Coverage.UncoveredOnDemandCost, 0.114) {
+		t.Errorf("unexpected c4 coverage: %+v", c4Coverage)
+	}
+	if largeCoverage.CoveredCount != 1 || largeCoverage.UncoveredOnDemandCost != 0 {
+		t.Errorf("unexpected large coverage: %+v", largeCoverage)
+	}
+	if sqlCoverage.Eligible || sqlCoverage.CoveredCount != 0 || !almostEqual(sqlCoverage.UncoveredOnDemandCost, 0.2) {
+		t.Errorf("unexpected linux with sql coverage: %+v", sqlCoverage)
+	}
+
+	if coverage, err = SizeFlexibleReservation(reserved, "4NA7Y494T4", 8, usage[:1], CurrencyUSD); err != nil || coverage.UnusedNormalizedUnits != 16 {
+		t.Errorf("expected 16 unused normalized units but got: %+v %+v", coverage, err)
+	}
+	if _, err = SizeFlexibleReservation(reserved, "MISSING", 1, usage, CurrencyUSD); err == nil {
+		t.Error("expected error for missing offer")
+	}
+	sqlReserved := reserved
+	sqlReserved.Product.Attributes.PreInstalledSw = "SQL Std"
+	sqlReserved.Product.Attributes.Operation = "RunInstances:0004"
+	if _, err = SizeFlexibleReservation(sqlReserved, "4NA7Y494T4", 1, usage, CurrencyUSD); err == nil {
+		t.Error("expected error for linux with sql reservation")
+	}
+	reserved.Product.Attributes.OperatingSystem = "Windows"
+	if _, err = SizeFlexibleReservation(reserved, "4NA7Y494T4", 1, usage, CurrencyUSD); err == nil {
+		t.Error("expected error for windows reservation")
+	}
+}
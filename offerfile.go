@@ -0,0 +1,59 @@
+package awsPricingTyper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/pricing"
+)
+
+// offerFile is the layout of the bulk offer files published by AWS, e.g.
+// https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws/AmazonEC2/current/index.json
+type offerFile struct {
+	FormatVersion   string                                       `json:"formatVersion"`
+	OfferCode       string                                       `json:"offerCode"`
+	Version         string                                       `json:"version"`
+	PublicationDate string                                       `json:"publicationDate"`
+	Products        map[string]map[string]interface{}            `json:"products"`
+	Terms           map[string]map[string]map[string]interface{} `json:"terms"`
+}
+
+// ReadOfferFile reads a bulk offer file and returns it in the form returned by GetProducts
+// so that it can be passed to GetTypedPricingData
+func ReadOfferFile(r io.Reader) (output pricing.GetProductsOutput, err error) {
+	var of offerFile
+	if err = json.NewDecoder(r).Decode(&of); err != nil {
+		return output, fmt.Errorf("failed to decode offer file: %+v", err)
+	}
+	if of.Products == nil {
+		return output, fmt.Errorf("offer file contains no products")
+	}
+
+	skus := make([]string, 0, len(of.Products))
+	for sku := range of.Products {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	formatVersion := "aws_v1"
+	output.FormatVersion = &formatVersion
+	for _, sku := range skus {
+		terms := make(map[string]interface{})
+		for termType, termsBySKU := range of.Terms {
+			if skuTerms, ok := termsBySKU[sku]; ok {
+				terms[termType] = skuTerms
+			}
+		}
+		output.PriceList = append(output.PriceList, aws.JSONValue{
+			"serviceCode":     of.OfferCode,
+			"version":         of.Version,
+			"publicationDate": of.PublicationDate,
+			"product":         of.Products[sku],
+			"terms":           terms,
+		})
+	}
+	return output, nil
+}
@@ -0,0 +1,168 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const parquetParallelism = 4
+
+// ParquetRow is the flattened, columnar representation of a single price
+// (one per SKU, term, rate code and currency) written by ParquetWriter
+type ParquetRow struct {
+	PublicationDate         string   `parquet:"name=publication_date, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Version                 string   `parquet:"name=version, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	ServiceCode             string   `parquet:"name=service_code, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	SKU                     string   `parquet:"name=sku, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ProductFamily           string   `parquet:"name=product_family, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Location                string   `parquet:"name=location, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	LocationType            string   `parquet:"name=location_type, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	InstanceType            string   `parquet:"name=instance_type, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	InstanceFamily          string   `parquet:"name=instance_family, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	OperatingSystem         string   `parquet:"name=operating_system, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Tenancy                 string   `parquet:"name=tenancy, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	LicenseModel            string   `parquet:"name=license_model, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	PreInstalledSw          string   `parquet:"name=pre_installed_sw, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	CapacityStatus          string   `parquet:"name=capacity_status, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Operation               string   `parquet:"name=operation, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	UsageType               string   `parquet:"name=usage_type, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	VCPU                    *int32   `parquet:"name=vcpu, type=INT32, repetitiontype=OPTIONAL"`
+	MemoryGiB               *float64 `parquet:"name=memory_gib, type=DOUBLE, repetitiontype=OPTIONAL"`
+	ECU                     *float64 `parquet:"name=ecu, type=DOUBLE, repetitiontype=OPTIONAL"`
+	NormalizationSizeFactor *float64 `parquet:"name=normalization_size_factor, type=DOUBLE, repetitiontype=OPTIONAL"`
+	TermType                string   `parquet:"name=term_type, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	OfferTermCode           string   `parquet:"name=offer_term_code, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	EffectiveDate           string   `parquet:"name=effective_date, type=BYTE_ARRAY, convertedtype=UTF8"`
+	LeaseContractLength     string   `parquet:"name=lease_contract_length, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	OfferingClass           string   `parquet:"name=offering_class, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	PurchaseOption          string   `parquet:"name=purchase_option, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	RateCode                string   `parquet:"name=rate_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Description             string   `parquet:"name=description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Unit                    string   `parquet:"name=unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	BeginRange              *float64 `parquet:"name=begin_range, type=DOUBLE, repetitiontype=OPTIONAL"`
+	EndRange                *float64 `parquet:"name=end_range, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Currency                string   `parquet:"name=currency, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Price                   float64  `parquet:"name=price, type=DOUBLE"`
+}
+
+// ParquetWriter streams PricingDocuments to an io.Writer as Parquet
+// Documents may be written in batches, e.g. one page of GetProducts output at a time,
+// and the file is only complete once Close has been called
+type ParquetWriter struct {
+	pw *writer.ParquetWriter
+}
+
+// NewParquetWriter returns a ParquetWriter that writes to w
+func NewParquetWriter(w io.Writer) (*ParquetWriter, error) {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(ParquetRow), parquetParallelism)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parquet writer: %+v", err)
+	}
+	return &ParquetWriter{pw: pw}, nil
+}
+
+// Write flattens the documents and appends their rows to the output
+func (w *ParquetWriter) Write(docs ...PricingDocument) error {
+	for _, doc := range docs {
+		for _, row := range parquetRows(doc) {
+			if err := w.pw.Write(row); err != nil {
+				return fmt.Errorf("failed to write parquet row for sku %s: %+v", row.SKU, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Close flushes any buffered rows and writes the parquet footer
+// It does not close the underlying io.Writer
+func (w *ParquetWriter) Close() error {
+	if err := w.pw.WriteStop(); err != nil {
+		return fmt.Errorf("failed to finalise parquet output: %+v", err)
+	}
+	return nil
+}
+
+// WriteParquet writes the documents to w as a complete Parquet file
+func WriteParquet(w io.Writer, docs []PricingDocument) error {
+	pw, err := NewParquetWriter(w)
+	if err != nil {
+		return err
+	}
+	if err = pw.Write(docs...); err != nil {
+		return err
+	}
+	return pw.Close()
+}
+
+func parquetRows(doc PricingDocument) (rows []ParquetRow) {
+	attrs := doc.Product.Attributes
+	base := ParquetRow{
+		PublicationDate:         doc.PublicationDate,
+		Version:                 doc.Version,
+		ServiceCode:             doc.ServiceCode,
+		SKU:                     doc.Product.SKU,
+		ProductFamily:           doc.Product.ProductFamily,
+		Location:                attrs.Location,
+		LocationType:            attrs.LocationType,
+		InstanceType:            attrs.InstanceType,
+		InstanceFamily:          attrs.InstanceFamily,
+		OperatingSystem:         attrs.OperatingSystem,
+		Tenancy:                 attrs.Tenancy,
+		LicenseModel:            attrs.LicenseModel,
+		PreInstalledSw:          attrs.PreInstalledSw,
+		CapacityStatus:          attrs.CapacityStatus,
+		Operation:               attrs.Operation,
+		UsageType:               attrs.UsageType,
+		VCPU:                    parseAttributeInt32(attrs.VCPU),
+		MemoryGiB:               parseMemoryGiB(attrs.Memory),
+		ECU:                     parseAttributeFloat(attrs.ECU),
+		NormalizationSizeFactor: parseAttributeFloat(attrs.NormalizationSizeFactor),
+	}
+
+	walkRates(doc, func(r Rate) {
+		row := base
+		row.TermType = r.TermType
+		row.OfferTermCode = r.OfferTermCode
+		row.EffectiveDate = r.EffectiveDate
+		row.LeaseContractLength = r.TermAttributes.LeaseContractLength
+		row.OfferingClass = r.TermAttributes.OfferingClass
+		row.PurchaseOption = r.TermAttributes.PurchaseOption
+		row.RateCode = r.RateCode
+		row.Description = r.Description
+		row.Unit = r.Unit
+		beginRange := r.BeginRange
+		row.BeginRange = &beginRange
+		row.EndRange = r.EndRange
+		row.Currency = r.Currency
+		row.Price = r.Price
+		rows = append(rows, row)
+	})
+	return rows
+}
+
+// parseAttributeFloat converts numeric attribute values such as "6.5" or "1,952"
+// returning nil for non-numeric values such as "NA" or "Variable"
+func parseAttributeFloat(value string) *float64 {
+	value = strings.Replace(strings.TrimSpace(value), ",", "", -1)
+	if value == "Inf" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func parseAttributeInt32(value string) *int32 {
+	i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return nil
+	}
+	i32 := int32(i)
+	return &i32
+}
@@ -0,0 +1,57 @@
+package awsPricingTyper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestWriteParquet(t *testing.T) {
+	docs := getMockPricingDocuments(t)
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, docs); err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+
+	pr, err := reader.NewParquetReader(buffer.NewBufferFileFromBytes(buf.Bytes()), new(ParquetRow), 1)
+	if err != nil {
+		t.Fatalf("failed to read parquet output: %+v", err)
+	}
+	defer pr.ReadStop()
+	if pr.GetNumRows() != 2 {
+		t.Fatalf("expected 2 rows but got: %d", pr.GetNumRows())
+	}
+	rows := make([]ParquetRow, pr.GetNumRows())
+	if err = pr.Read(&rows); err != nil {
+		t.Fatalf("failed to read parquet rows: %+v", err)
+	}
+
+	onDemand := rows[0]
+	if onDemand.TermType != TermTypeOnDemand || onDemand.Currency != "USD" || onDemand.Price != 0.111 {
+		t.Errorf("unexpected on demand row: %+v", onDemand)
+	}
+	if onDemand.VCPU == nil || *onDemand.VCPU != 2 {
+		t.Errorf("expected vcpu of 2 but got: %v", onDemand.VCPU)
+	}
+	if onDemand.MemoryGiB == nil || *onDemand.MemoryGiB != 8 {
+		t.Errorf("expected memory of 8 GiB but got: %v", onDemand.MemoryGiB)
+	}
+	if onDemand.EndRange != nil {
+		t.Errorf("expected nil end range for Inf but got: %v", *onDemand.EndRange)
+	}
+	reserved := rows[1]
+	if reserved.TermType != TermTypeReserved || reserved.Price != 0.0756 || reserved.LeaseContractLength != "1yr" {
+		t.Errorf("unexpected reserved row: %+v", reserved)
+	}
+}
+
+func TestParseAttributeFloat(t *testing.T) {
+	if v := parseAttributeFloat("1,952"); v == nil || *v != 1952 {
+		t.Errorf("expected 1952 but got: %v", v)
+	}
+	if v := parseAttributeFloat("Variable"); v != nil {
+		t.Errorf("expected nil but got: %v", *v)
+	}
+}
@@ -0,0 +1,78 @@
+package awsPricingTyper
+
+import "fmt"
+
+// Platform describes the software an instance runs as encoded by the operation of its usage
+type Platform struct {
+	// Operation is the operation attribute of products and line item operation of billing, e.g. RunInstances:0002
+	Operation string
+	// Details is the platform details reported by EC2 DescribeInstances, e.g. Windows with SQL Server Standard
+	Details string
+	// OperatingSystem is the operatingSystem attribute of products with the operation, e.g. Windows
+	OperatingSystem string
+	// PreInstalledSw is the preInstalledSw attribute of products with the operation, e.g. SQL Std
+	PreInstalledSw string
+	// BYOL is true if the operation is for instances with a license brought by the customer
+	BYOL bool
+}
+
+// Platforms lists the platforms of the RunInstances operations known to the library
+var Platforms = []Platform{
+	{Operation: "RunInstances", Details: "Linux/UNIX", OperatingSystem: "Linux", PreInstalledSw: "NA"},
+	{Operation: "RunInstances:0004", Details: "Linux with SQL Server Standard", OperatingSystem: "Linux", PreInstalledSw: "SQL Std"},
+	{Operation: "RunInstances:0200", Details: "Linux with SQL Server Web", OperatingSystem: "Linux", PreInstalledSw: "SQL Web"},
+	{Operation: "RunInstances:0100", Details: "Linux with SQL Server Enterprise", OperatingSystem: "Linux", PreInstalledSw: "SQL Ent"},
+	{Operation: "RunInstances:0002", Details: "Windows", OperatingSystem: "Windows", PreInstalledSw: "NA"},
+	{Operation: "RunInstances:0800", Details: "Windows BYOL", OperatingSystem: "Windows", PreInstalledSw: "NA", BYOL: true},
+	{Operation: "RunInstances:0006", Details: "Windows with SQL Server Standard", OperatingSystem: "Windows", PreInstalledSw: "SQL Std"},
+	{Operation: "RunInstances:0202", Details: "Windows with SQL Server Web", OperatingSystem: "Windows", PreInstalledSw: "SQL Web"},
+	{Operation: "RunInstances:0102", Details: "Windows with SQL Server Enterprise", OperatingSystem: "Windows", PreInstalledSw: "SQL Ent"},
+	{Operation: "RunInstances:0010", Details: "Red Hat Enterprise Linux", OperatingSystem: "RHEL", PreInstalledSw: "NA"},
+	{Operation: "RunInstances:0014", Details: "Red Hat Enterprise Linux with SQL Server Standard", OperatingSystem: "RHEL", PreInstalledSw: "SQL Std"},
+	{Operation: "RunInstances:0210", Details: "Red Hat Enterprise Linux with SQL Server Web", OperatingSystem: "RHEL", PreInstalledSw: "SQL Web"},
+	{Operation: "RunInstances:0110", Details: "Red Hat Enterprise Linux with SQL Server Enterprise", OperatingSystem: "RHEL", PreInstalledSw: "SQL Ent"},
+	{Operation: "RunInstances:1010", Details: "Red Hat Enterprise Linux with HA", OperatingSystem: "Red Hat Enterprise Linux with HA", PreInstalledSw: "NA"},
+	{Operation: "RunInstances:1014", Details: "Red Hat Enterprise Linux with SQL Server Standard and HA", OperatingSystem: "Red Hat Enterprise Linux with HA", PreInstalledSw: "SQL Std"},
+	{Operation: "RunInstances:1110", Details: "Red Hat Enterprise Linux with SQL Server Enterprise and HA", OperatingSystem: "Red Hat Enterprise Linux with HA", PreInstalledSw: "SQL Ent"},
+	{Operation: "RunInstances:000g", Details: "SUSE Linux", OperatingSystem: "SUSE", PreInstalledSw: "NA"},
+	{Operation: "RunInstances:0g00", Details: "Ubuntu Pro", OperatingSystem: "Ubuntu Pro", PreInstalledSw: "NA"},
+}
+
+// PlatformByOperation returns the platform of an operation, e.g. RunInstances:0002
+func PlatformByOperation(operation string) (Platform, bool) {
+	for _, p := range Platforms {
+		if p.Operation == operation {
+			return p, true
+		}
+	}
+	return Platform{}, false
+}
+
+// PlatformByDetails returns the platform with the given platform details, e.g. Windows
+func PlatformByDetails(details string) (Platform, bool) {
+	for _, p := range Platforms {
+		if p.Details == details {
+			return p, true
+		}
+	}
+	return Platform{}, false
+}
+
+// Platform returns the platform decoded from the document's operation, returning an error if the
+// operation is unknown or the platform disagrees with the document's operating system or pre-installed software
+func (doc PricingDocument) Platform() (Platform, error) {
+	attrs := doc.Product.Attributes
+	p, ok := PlatformByOperation(attrs.Operation)
+	if !ok {
+		return Platform{}, fmt.Errorf("unknown operation %q for sku: %s", attrs.Operation, doc.Product.SKU)
+	}
+	if attrs.OperatingSystem != "" && attrs.OperatingSystem != p.OperatingSystem {
+		return Platform{}, fmt.Errorf("operation %s is for operating system %s but sku: %s has %s",
+			p.Operation, p.OperatingSystem, doc.Product.SKU, attrs.OperatingSystem)
+	}
+	if attrs.PreInstalledSw != "" && attrs.PreInstalledSw != p.PreInstalledSw {
+		return Platform{}, fmt.Errorf("operation %s is for pre-installed software %s but sku: %s has %s",
+			p.Operation, p.PreInstalledSw, doc.Product.SKU, attrs.PreInstalledSw)
+	}
+	return p, nil
+}
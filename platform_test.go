@@ -0,0 +1,50 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestPlatformsAreUnique(t *testing.T) {
+	operations := make(map[string]bool)
+	details := make(map[string]bool)
+	for _, p := range Platforms {
+		if operations[p.Operation] || details[p.Details] {
+			t.Errorf("duplicate platform: %+v", p)
+		}
+		operations[p.Operation] = true
+		details[p.Details] = true
+	}
+}
+
+func TestDocumentPlatform(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	p, err := doc.Platform()
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if p.Details != "Linux/UNIX" {
+		t.Errorf("expected Linux/UNIX but got: %+v", p)
+	}
+
+	doc.Product.Attributes.Operation = "RunInstances:0006"
+	doc.Product.Attributes.OperatingSystem = "Windows"
+	doc.Product.Attributes.PreInstalledSw = "SQL Std"
+	if p, err = doc.Platform(); err != nil || p.Details != "Windows with SQL Server Standard" {
+		t.Errorf("expected Windows with SQL Server Standard but got: %+v %+v", p, err)
+	}
+	if byDetails, ok := PlatformByDetails(p.Details); !ok || byDetails.Operation != "RunInstances:0006" {
+		t.Errorf("unexpected platform by details: %+v", byDetails)
+	}
+
+	doc.Product.Attributes.PreInstalledSw = "NA"
+	if _, err = doc.Platform(); err == nil {
+		t.Error("expected error for mismatched pre-installed software")
+	}
+	doc.Product.Attributes.OperatingSystem = "RHEL"
+	doc.Product.Attributes.Operation = "RunInstances:0002"
+	if _, err = doc.Platform(); err == nil {
+		t.Error("expected error for mismatched operating system")
+	}
+	doc.Product.Attributes.Operation = "RunInstances:9999"
+	if _, err = doc.Platform(); err == nil {
+		t.Error("expected error for unknown operation")
+	}
+}
@@ -0,0 +1,148 @@
+package awsPricingTyper
+
+import (
+	"sort"
+	"time"
+)
+
+// Term types of a PricingDocument
+const (
+	TermTypeOnDemand = "OnDemand"
+	TermTypeReserved = "Reserved"
+)
+
+// Rate is a single price of a PricingDocument flattened together with the term it belongs to
+// Reserved terms have TermAttributes whereas they are empty for OnDemand terms
+type Rate struct {
+	SKU            string
+	TermType       string
+	OfferTermCode  string
+	EffectiveDate  string
+	EffectiveTime  time.Time
+	TermAttributes ReservedTermAttributes
+	RateCode       string
+	Description    string
+	Unit           string
+	BeginRange     float64
+	// EndRange is nil if the rate is unbounded, i.e. has an end range of Inf
+	EndRange *float64
+	Currency string
+	Price    float64
+}
+
+// Rates returns the document's prices as flattened Rates
+// OnDemand rates are returned before Reserved, and terms, rate codes and currencies in order
+func (doc PricingDocument) Rates() (rates []Rate) {
+	walkRates(doc, func(r Rate) {
+		rates = append(rates, r)
+	})
+	return rates
+}
+
+// RatesAt returns the document's Rates whose terms are in effect at the given time,
+// i.e. those with an effective time that is not after it
+// Terms without an effective time, e.g. one that could not be parsed, are never in effect
+func (doc PricingDocument) RatesAt(t time.Time) (rates []Rate) {
+	walkRates(doc, func(r Rate) {
+		if inEffect(r.EffectiveTime, t) {
+			rates = append(rates, r)
+		}
+	})
+	return rates
+}
+
+// inEffect reports whether a term with the effective time is in effect at the time
+func inEffect(effective, t time.Time) bool {
+	return !effective.IsZero() && !effective.After(t)
+}
+
+// GetRates returns the flattened Rates of all of the documents
+func GetRates(docs []PricingDocument) (rates []Rate) {
+	for _, doc := range docs {
+		rates = append(rates, doc.Rates()...)
+	}
+	return rates
+}
+
+// walkRates calls fn for every price in the document's terms in the order returned by Rates
+func walkRates(doc PricingDocument, fn func(r Rate)) {
+	for _, code := range sortedOnDemandTermCodes(doc.Terms.OnDemand) {
+		term := doc.Terms.OnDemand[code]
+		walkPriceDimensions(Rate{
+			SKU:           termSKU(doc, term.SKU),
+			TermType:      TermTypeOnDemand,
+			OfferTermCode: term.OfferTermCode,
+			EffectiveDate: term.EffectiveDate,
+			EffectiveTime: term.EffectiveTime,
+		}, term.PriceDimensions, fn)
+	}
+	for _, code := range sortedReservedTermCodes(doc.Terms.Reserved) {
+		term := doc.Terms.Reserved[code]
+		walkPriceDimensions(Rate{
+			SKU:            termSKU(doc, term.SKU),
+			TermType:       TermTypeReserved,
+			OfferTermCode:  term.OfferTermCode,
+			EffectiveDate:  term.EffectiveDate,
+			EffectiveTime:  term.EffectiveTime,
+			TermAttributes: term.TermAttributes,
+		}, term.PriceDimensions, fn)
+	}
+}
+
+func walkPriceDimensions(base Rate, priceDimensions []PriceDimension, fn func(r Rate)) {
+	for _, pd := range priceDimensions {
+		for _, rateCode := range sortedPriceDimensionRateCodes(pd) {
+			item := pd[rateCode]
+			r := base
+			r.RateCode = rateCode
+			r.Description = item.Description
+			r.Unit = item.Unit
+			if beginRange := parseAttributeFloat(item.BeginRange); beginRange != nil {
+				r.BeginRange = *beginRange
+			}
+			r.EndRange = parseAttributeFloat(item.EndRange)
+			for _, ppu := range item.PricePerUnit {
+				for _, currency := range ppu.Currencies() {
+					r.Currency = currency
+					r.Price = ppu[currency]
+					fn(r)
+				}
+			}
+		}
+	}
+}
+
+// termSKU returns the SKU of a term falling back to that of the document's product
+func termSKU(doc PricingDocument, sku string) string {
+	if sku != "" {
+		return sku
+	}
+	return doc.Product.SKU
+}
+
+func sortedOnDemandTermCodes(terms map[string]OnDemandTerm) []string {
+	codes := make([]string, 0, len(terms))
+	for code := range terms {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func sortedReservedTermCodes(terms map[string]ReservedTerm) []string {
+	codes := make([]string, 0, len(terms))
+	for code := range terms {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func sortedPriceDimensionRateCodes(pd PriceDimension) []string {
+	rateCodes := make([]string, 0, len(pd))
+	for rateCode := range pd {
+		rateCodes = append(rateCodes, rateCode)
+	}
+	sort.Strings(rateCodes)
+	return rateCodes
+}
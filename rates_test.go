@@ -0,0 +1,55 @@
+package awsPricingTyper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRates(t *testing.T) {
+	rates := GetRates(getMockPricingDocuments(t))
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 rates but got: %+v", rates)
+	}
+	onDemand, reserved := rates[0], rates[1]
+	if onDemand.SKU != "7X4K64YA59VZZAC3" || onDemand.TermType != TermTypeOnDemand || onDemand.OfferTermCode != "JRTCKXETXF" {
+		t.Errorf("unexpected on demand rate: %+v", onDemand)
+	}
+	if onDemand.RateCode != "ABCDEFGHIJK.LMNOPQRST.UVWXYZ" || onDemand.Unit != "Hrs" || onDemand.Currency != CurrencyUSD || onDemand.Price != 0.111 {
+		t.Errorf("unexpected on demand rate: %+v", onDemand)
+	}
+	if onDemand.BeginRange != 0 || onDemand.EndRange != nil {
+		t.Errorf("unexpected on demand range: %f %v", onDemand.BeginRange, onDemand.EndRange)
+	}
+	if reserved.TermType != TermTypeReserved || reserved.TermAttributes.PurchaseOption != "No Upfront" || reserved.Price != 0.0756 {
+		t.Errorf("unexpected reserved rate: %+v", reserved)
+	}
+}
+
+func TestRatesAt(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	// the mock's Reserved term is effective from 2017-04-30 and its OnDemand term from 2018-07-01
+	rates := doc.RatesAt(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(rates) != 1 || rates[0].TermType != TermTypeReserved {
+		t.Errorf("expected only the reserved rate but got: %+v", rates)
+	}
+	rates = doc.RatesAt(time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC))
+	if len(rates) != 2 {
+		t.Errorf("expected 2 rates but got: %+v", rates)
+	}
+	if rates = doc.RatesAt(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)); len(rates) != 0 {
+		t.Errorf("expected no rates but got: %+v", rates)
+	}
+
+	// a term whose effective date could not be parsed is never in effect
+	onDemand := doc.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"]
+	onDemand.EffectiveDate = "2018-07-01"
+	onDemand.EffectiveTime = time.Time{}
+	doc.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"] = onDemand
+	for _, at := range []time.Time{time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), time.Now()} {
+		for _, rate := range doc.RatesAt(at) {
+			if rate.TermType == TermTypeOnDemand {
+				t.Errorf("expected no on demand rate at %s but got: %+v", at, rate)
+			}
+		}
+	}
+}
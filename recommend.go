@@ -0,0 +1,183 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const hoursPerYear = 8760
+
+// networkPerformanceRanks orders the named networkPerformance attribute values, all slower than
+// the values given in Gigabit, from slowest to fastest
+var networkPerformanceRanks = map[string]int{
+	"Very Low":        1,
+	"Low":             2,
+	"Low to Moderate": 3,
+	"Moderate":        4,
+	"High":            5,
+}
+
+// networkPerformanceRank ranks a networkPerformance attribute value from slowest to fastest
+// Values in Gigabit, e.g. 20 Gigabit or Up to 25 Gigabit, are ranked by their peak bandwidth with
+// burstable "Up to" values ranked just below sustained values of the same bandwidth
+func networkPerformanceRank(value string) (int, bool) {
+	if rank, ok := networkPerformanceRanks[value]; ok {
+		return rank, true
+	}
+	gbps := strings.TrimSuffix(value, " Gigabit")
+	if gbps == value {
+		return 0, false
+	}
+	burstable := strings.HasPrefix(gbps, "Up to ")
+	bandwidth, err := strconv.ParseFloat(strings.TrimPrefix(gbps, "Up to "), 64)
+	if err != nil || bandwidth <= 0 {
+		return 0, false
+	}
+	rank := len(networkPerformanceRanks) + 1 + int(bandwidth*20)*2
+	if !burstable {
+		rank++
+	}
+	return rank, true
+}
+
+// Requirements describes the minimum specification of instances to recommend
+// Zero values are not used to filter instances
+type Requirements struct {
+	Location              string
+	MinVCPU               int
+	MinMemoryGiB          float64
+	MinGPU                int
+	ProcessorArchitecture string
+	MinNetworkPerformance string
+	OperatingSystem       string
+	Tenancy               string
+	Currency              string
+	// ExchangeRates, if set, converts the prices of documents not priced in the currency, e.g. CNY
+	// prices of regions in China when recommending in USD
+	ExchangeRates        ExchangeRateProvider
+	SortByReservedHourly bool
+}
+
+// Recommendation is an instance that meets the requirements along with its hourly prices
+type Recommendation struct {
+	Document       PricingDocument
+	InstanceType   string
+	OnDemandHourly float64
+	// HasReserved is false if the document has no Reserved terms in the currency
+	HasReserved bool
+	// BestReservedHourly is the lowest effective hourly price, including any upfront fee
+	// spread across the lease, of the document's Reserved terms
+	BestReservedHourly        float64
+	BestReservedOfferTermCode string
+}
+
+// Recommend returns the documents that meet the requirements ordered by on-demand hourly price
+// or, if requested, by the best Reserved hourly price with documents without Reserved terms last
+// An error is returned if the minimum network performance is not a known value, and documents with
+// a network performance that is not known do not meet a minimum
+func Recommend(docs []PricingDocument, req Requirements) (recs []Recommendation, err error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = CurrencyUSD
+	}
+	minNetworkRank := 0
+	if req.MinNetworkPerformance != "" {
+		var ok bool
+		if minNetworkRank, ok = networkPerformanceRank(req.MinNetworkPerformance); !ok {
+			return nil, fmt.Errorf("unknown network performance: %s", req.MinNetworkPerformance)
+		}
+	}
+	for _, doc := range docs {
+		if !meetsRequirements(doc, req, minNetworkRank) {
+			continue
+		}
+		onDemand, onDemandErr := doc.OnDemandHourlyIn(currency, req.ExchangeRates)
+		if onDemandErr != nil {
+			continue
+		}
+		rec := Recommendation{
+			Document:       doc,
+			InstanceType:   doc.Product.Attributes.InstanceType,
+			OnDemandHourly: onDemand,
+		}
+		for _, offer := range doc.ReservedOffersIn(currency, req.ExchangeRates) {
+			if !rec.HasReserved || offer.EffectiveHourly < rec.BestReservedHourly {
+				rec.HasReserved = true
+				rec.BestReservedHourly = offer.EffectiveHourly
+				rec.BestReservedOfferTermCode = offer.OfferTermCode
+			}
+		}
+		recs = append(recs, rec)
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool {
+		a, b := recs[i], recs[j]
+		if req.SortByReservedHourly {
+			if a.HasReserved != b.HasReserved {
+				return a.HasReserved
+			}
+			if a.BestReservedHourly != b.BestReservedHourly {
+				return a.BestReservedHourly < b.BestReservedHourly
+			}
+		}
+		if a.OnDemandHourly != b.OnDemandHourly {
+			return a.OnDemandHourly < b.OnDemandHourly
+		}
+		return a.InstanceType < b.InstanceType
+	})
+	return recs, nil
+}
+
+func meetsRequirements(doc PricingDocument, req Requirements, minNetworkRank int) bool {
+	attrs := doc.Product.Attributes
+	switch {
+	case req.Location != "" && attrs.Location != req.Location:
+		return false
+	case req.OperatingSystem != "" && attrs.OperatingSystem != req.OperatingSystem:
+		return false
+	case req.Tenancy != "" && attrs.Tenancy != req.Tenancy:
+		return false
+	case req.ProcessorArchitecture != "" && attrs.ProcessorArchitecture != req.ProcessorArchitecture:
+		return false
+	case req.MinVCPU > 0 && attributeInt(attrs.VCPU) < req.MinVCPU:
+		return false
+	case req.MinGPU > 0 && attributeInt(attrs.GPU) < req.MinGPU:
+		return false
+	}
+	if minNetworkRank > 0 {
+		if rank, ok := networkPerformanceRank(attrs.NetworkPerformance); !ok || rank < minNetworkRank {
+			return false
+		}
+	}
+	if req.MinMemoryGiB > 0 {
+		memory := parseMemoryGiB(attrs.Memory)
+		if memory == nil || *memory < req.MinMemoryGiB {
+			return false
+		}
+	}
+	return true
+}
+
+// leaseHours converts a LeaseContractLength, e.g. 1yr or 3yr, to hours
+func leaseHours(leaseContractLength string) float64 {
+	years, err := strconv.Atoi(strings.TrimSuffix(leaseContractLength, "yr"))
+	if err != nil {
+		return 0
+	}
+	return float64(years * hoursPerYear)
+}
+
+func attributeInt(value string) int {
+	i := parseAttributeInt32(value)
+	if i == nil {
+		return 0
+	}
+	return int(*i)
+}
+
+// parseMemoryGiB converts memory attribute values, e.g. 8 GiB, to a number of GiB
+func parseMemoryGiB(memory string) *float64 {
+	return parseAttributeFloat(strings.TrimSuffix(memory, " GiB"))
+}
@@ -0,0 +1,100 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestRecommend(t *testing.T) {
+	large := getMockInstanceDocument(t, "m4.large", "2", "8 GiB", 0.111)
+	xlarge := getMockInstanceDocument(t, "m4.xlarge", "4", "16 GiB", 0.222)
+	c4 := getMockInstanceDocument(t, "c4.xlarge", "4", "7.5 GiB", 0.199)
+	r4 := getMockInstanceDocument(t, "r4.xlarge", "4", "30.5 GiB", 0.296)
+	// all upfront reserved term costing less per hour than xlarge's on demand price
+	r4.Terms.Reserved = map[string]ReservedTerm{
+		"r4.xlarge.6QCMYABX3D": {
+			OfferTermCode: "6QCMYABX3D",
+			TermAttributes: ReservedTermAttributes{
+				LeaseContractLength: "1yr",
+				PurchaseOption:      "All Upfront",
+			},
+			PriceDimensions: []PriceDimension{{
+				"r4.xlarge.6QCMYABX3D.2TG2D8R56U": {Unit: "Quantity", PricePerUnit: []PricePerUnit{{"USD": 1314}}},
+				"r4.xlarge.6QCMYABX3D.6YS6EN2CT7": {Unit: "Hrs", PricePerUnit: []PricePerUnit{{"USD": 0}}},
+			}},
+		},
+	}
+	docs := []PricingDocument{r4, large, xlarge, c4}
+
+	recs, err := Recommend(docs, Requirements{Location: "EU (Ireland)", MinVCPU: 4, MinMemoryGiB: 8})
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if len(recs) != 2 || recs[0].InstanceType != "m4.xlarge" || recs[1].InstanceType != "r4.xlarge" {
+		t.Fatalf("unexpected recommendations: %+v", recs)
+	}
+	if recs[0].HasReserved {
+		t.Errorf("expected no reserved price for m4.xlarge but got: %f", recs[0].BestReservedHourly)
+	}
+	if recs[1].BestReservedHourly != 0.15 || recs[1].BestReservedOfferTermCode != "6QCMYABX3D" {
+		t.Errorf("unexpected best reserved price: %f %s", recs[1].BestReservedHourly, recs[1].BestReservedOfferTermCode)
+	}
+
+	recs, err = Recommend(docs, Requirements{MinVCPU: 4, MinMemoryGiB: 8, SortByReservedHourly: true})
+	if err != nil || len(recs) != 2 || recs[0].InstanceType != "r4.xlarge" {
+		t.Errorf("expected r4.xlarge first when sorting by reserved price but got: %+v", recs)
+	}
+
+	if recs, err = Recommend(docs, Requirements{MinNetworkPerformance: "High"}); err != nil || len(recs) != 0 {
+		t.Errorf("expected no recommendations for Moderate network performance but got: %+v %+v", recs, err)
+	}
+	if _, err = Recommend(docs, Requirements{MinNetworkPerformance: "Fast"}); err == nil {
+		t.Error("expected error for unknown minimum network performance")
+	}
+}
+
+func TestRecommendWithExchangeRates(t *testing.T) {
+	rates := StaticExchangeRates{Base: CurrencyUSD, Rates: map[string]float64{CurrencyCNY: 6.9}}
+	beijing := pricedInCNY(getMockInstanceDocument(t, "m4.xlarge", "4", "16 GiB", 0.2), 6.9)
+	ireland := getMockInstanceDocument(t, "c4.xlarge", "4", "7.5 GiB", 0.199)
+	docs := []PricingDocument{beijing, ireland}
+
+	if recs, err := Recommend(docs, Requirements{MinVCPU: 4}); err != nil || len(recs) != 1 {
+		t.Errorf("expected only the USD priced document without exchange rates but got: %+v %+v", recs, err)
+	}
+	recs, err := Recommend(docs, Requirements{MinVCPU: 4, ExchangeRates: rates})
+	if err != nil || len(recs) != 2 {
+		t.Fatalf("expected both documents but got: %+v %+v", recs, err)
+	}
+	if recs[0].InstanceType != "c4.xlarge" || recs[1].InstanceType != "m4.xlarge" || !almostEqual(recs[1].OnDemandHourly, 0.2) {
+		t.Errorf("unexpected recommendations: %+v", recs)
+	}
+}
+
+func TestRecommendNetworkPerformance(t *testing.T) {
+	var docs []PricingDocument
+	for _, np := range []string{"High", "12 Gigabit", "Up to 25 Gigabit", "20 Gigabit", "75 Gigabit", "Up to 50 Gigabit", "200 Gigabit", "Unknown"} {
+		doc := getMockInstanceDocument(t, np, "2", "8 GiB", 0.111)
+		doc.Product.Attributes.NetworkPerformance = np
+		docs = append(docs, doc)
+	}
+	tests := []struct {
+		min      string
+		expected int
+	}{
+		{"Moderate", 7},
+		{"10 Gigabit", 6},
+		{"20 Gigabit", 5},
+		{"Up to 25 Gigabit", 4},
+		{"25 Gigabit", 3},
+		{"Up to 50 Gigabit", 3},
+		{"100 Gigabit", 1},
+	}
+	for _, test := range tests {
+		recs, err := Recommend(docs, Requirements{MinNetworkPerformance: test.min})
+		if err != nil {
+			t.Errorf("got error: %+v", err)
+			continue
+		}
+		if len(recs) != test.expected {
+			t.Errorf("expected %d instances with at least %s but got: %d", test.expected, test.min, len(recs))
+		}
+	}
+}
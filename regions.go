@@ -0,0 +1,70 @@
+package awsPricingTyper
+
+// Region describes an AWS region as it is referred to by the pricing API
+type Region struct {
+	// Code is the region code used by the rest of the AWS API, e.g. eu-west-1
+	Code string
+	// Location is the name used for the location attribute of products, e.g. EU (Ireland)
+	Location string
+	// UsageTypePrefix is the prefix of usage types billed in the region, e.g. EU
+	UsageTypePrefix string
+}
+
+// Regions lists the regions known to the library
+var Regions = []Region{
+	{Code: "us-east-1", Location: "US East (N. Virginia)", UsageTypePrefix: "USE1"},
+	{Code: "us-east-2", Location: "US East (Ohio)", UsageTypePrefix: "USE2"},
+	{Code: "us-west-1", Location: "US West (N. California)", UsageTypePrefix: "USW1"},
+	{Code: "us-west-2", Location: "US West (Oregon)", UsageTypePrefix: "USW2"},
+	{Code: "ca-central-1", Location: "Canada (Central)", UsageTypePrefix: "CAN1"},
+	{Code: "sa-east-1", Location: "South America (Sao Paulo)", UsageTypePrefix: "SAE1"},
+	{Code: "eu-west-1", Location: "EU (Ireland)", UsageTypePrefix: "EU"},
+	{Code: "eu-west-2", Location: "EU (London)", UsageTypePrefix: "EUW2"},
+	{Code: "eu-west-3", Location: "EU (Paris)", UsageTypePrefix: "EUW3"},
+	{Code: "eu-central-1", Location: "EU (Frankfurt)", UsageTypePrefix: "EUC1"},
+	{Code: "eu-north-1", Location: "EU (Stockholm)", UsageTypePrefix: "EUN1"},
+	{Code: "eu-south-1", Location: "EU (Milan)", UsageTypePrefix: "EUS1"},
+	{Code: "ap-east-1", Location: "Asia Pacific (Hong Kong)", UsageTypePrefix: "APE1"},
+	{Code: "ap-northeast-1", Location: "Asia Pacific (Tokyo)", UsageTypePrefix: "APN1"},
+	{Code: "ap-northeast-2", Location: "Asia Pacific (Seoul)", UsageTypePrefix: "APN2"},
+	{Code: "ap-northeast-3", Location: "Asia Pacific (Osaka-Local)", UsageTypePrefix: "APN3"},
+	{Code: "ap-southeast-1", Location: "Asia Pacific (Singapore)", UsageTypePrefix: "APS1"},
+	{Code: "ap-southeast-2", Location: "Asia Pacific (Sydney)", UsageTypePrefix: "APS2"},
+	{Code: "ap-south-1", Location: "Asia Pacific (Mumbai)", UsageTypePrefix: "APS3"},
+	{Code: "me-south-1", Location: "Middle East (Bahrain)", UsageTypePrefix: "MES1"},
+	{Code: "af-south-1", Location: "Africa (Cape Town)", UsageTypePrefix: "AFS1"},
+	{Code: "us-gov-west-1", Location: "AWS GovCloud (US)", UsageTypePrefix: "UGW1"},
+	{Code: "us-gov-east-1", Location: "AWS GovCloud (US-East)", UsageTypePrefix: "UGE1"},
+	{Code: "cn-north-1", Location: "China (Beijing)", UsageTypePrefix: "CNN1"},
+	{Code: "cn-northwest-1", Location: "China (Ningxia)", UsageTypePrefix: "CNW1"},
+}
+
+// RegionByCode returns the region with the given code, e.g. eu-west-1
+func RegionByCode(code string) (Region, bool) {
+	for _, r := range Regions {
+		if r.Code == code {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
+
+// RegionByLocation returns the region with the given location name, e.g. EU (Ireland)
+func RegionByLocation(location string) (Region, bool) {
+	for _, r := range Regions {
+		if r.Location == location {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
+
+// RegionByUsageTypePrefix returns the region with the given usage type prefix, e.g. EU
+func RegionByUsageTypePrefix(prefix string) (Region, bool) {
+	for _, r := range Regions {
+		if r.UsageTypePrefix == prefix {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
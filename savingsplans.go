@@ -0,0 +1,270 @@
+package awsPricingTyper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Product families of Savings Plans
+const (
+	SavingsPlanFamilyCompute     = "ComputeSavingsPlans"
+	SavingsPlanFamilyEC2Instance = "EC2InstanceSavingsPlans"
+)
+
+// SavingsPlanProduct is a Savings Plan that can be purchased, e.g. a 1 year All Upfront Compute Savings Plan
+type SavingsPlanProduct struct {
+	SKU           string
+	ProductFamily string
+	ServiceCode   string
+	UsageType     string
+	Operation     string
+	Attributes    struct {
+		PurchaseOption string
+		Granularity    string
+		// InstanceType is the instance family of EC2 Instance Savings Plans, e.g. m5
+		InstanceType string
+		LocationType string
+		Location     string
+		PurchaseTerm string
+	}
+}
+
+// SavingsPlanRate is the price of usage, identified by the discounted SKU or usage type and operation,
+// when covered by a Savings Plan
+type SavingsPlanRate struct {
+	RateCode              string
+	DiscountedSKU         string
+	DiscountedUsageType   string
+	DiscountedOperation   string
+	DiscountedServiceCode string
+	Unit                  string
+	Currency              string
+	Price                 float64
+}
+
+// SavingsPlanTerm is the term of a Savings Plan and the rates of the usage it covers
+type SavingsPlanTerm struct {
+	SKU           string
+	Description   string
+	EffectiveDate string
+	// EffectiveTime is EffectiveDate parsed
+	EffectiveTime       time.Time
+	LeaseContractLength struct {
+		Duration int
+		Unit     string
+	}
+	Rates []SavingsPlanRate
+}
+
+// SavingsPlanDocument is a Savings Plan product and its term from a Savings Plan offer file
+type SavingsPlanDocument struct {
+	Version         string
+	PublicationDate string
+	// PublicationTime is PublicationDate parsed
+	PublicationTime time.Time
+	RegionCode      string
+	Product         SavingsPlanProduct
+	Term            SavingsPlanTerm
+}
+
+// savingsPlanOfferFile is the layout of the Savings Plan offer files published by AWS for each region, e.g.
+// https://pricing.us-east-1.amazonaws.com/savingsPlan/v1.0/aws/AWSComputeSavingsPlan/current/eu-west-1/index.json
+type savingsPlanOfferFile struct {
+	Version         string               `json:"version"`
+	PublicationDate string               `json:"publicationDate"`
+	RegionCode      string               `json:"regionCode"`
+	Products        []SavingsPlanProduct `json:"products"`
+	Terms           struct {
+		SavingsPlan []struct {
+			SKU                 string `json:"sku"`
+			Description         string `json:"description"`
+			EffectiveDate       string `json:"effectiveDate"`
+			LeaseContractLength struct {
+				Duration int    `json:"duration"`
+				Unit     string `json:"unit"`
+			} `json:"leaseContractLength"`
+			Rates []struct {
+				DiscountedSKU         string `json:"discountedSku"`
+				DiscountedUsageType   string `json:"discountedUsageType"`
+				DiscountedOperation   string `json:"discountedOperation"`
+				DiscountedServiceCode string `json:"discountedServiceCode"`
+				RateCode              string `json:"rateCode"`
+				Unit                  string `json:"unit"`
+				DiscountedRate        struct {
+					Price    string `json:"price"`
+					Currency string `json:"currency"`
+				} `json:"discountedRate"`
+			} `json:"rates"`
+		} `json:"savingsPlan"`
+	} `json:"terms"`
+}
+
+// ReadSavingsPlanOfferFile reads a Savings Plan offer file and returns a document for each of its Savings Plans
+// ordered by SKU
+// An error is returned if a price or the publication or effective date of a Savings Plan cannot be parsed
+func ReadSavingsPlanOfferFile(r io.Reader) (docs []SavingsPlanDocument, err error) {
+	var of savingsPlanOfferFile
+	if err = json.NewDecoder(r).Decode(&of); err != nil {
+		return nil, fmt.Errorf("failed to decode savings plan offer file: %+v", err)
+	}
+	if of.Products == nil {
+		return nil, fmt.Errorf("savings plan offer file contains no products")
+	}
+	publicationTime, err := time.Parse(time.RFC3339, of.PublicationDate)
+	if err != nil {
+		return nil, &TimestampParseError{Path: "publicationDate", Value: of.PublicationDate, Err: err}
+	}
+	var errs errorList
+	products := make(map[string]SavingsPlanProduct, len(of.Products))
+	for _, product := range of.Products {
+		products[product.SKU] = product
+	}
+	for i, t := range of.Terms.SavingsPlan {
+		product, ok := products[t.SKU]
+		if !ok {
+			return nil, fmt.Errorf("savings plan term has no product for sku: %s", t.SKU)
+		}
+		path := joinPath("terms", "savingsPlan", strconv.Itoa(i))
+		doc := SavingsPlanDocument{
+			Version:         of.Version,
+			PublicationDate: of.PublicationDate,
+			PublicationTime: publicationTime,
+			RegionCode:      of.RegionCode,
+			Product:         product,
+			Term: SavingsPlanTerm{
+				SKU:           t.SKU,
+				Description:   t.Description,
+				EffectiveDate: t.EffectiveDate,
+			},
+		}
+		doc.Term.EffectiveTime = errs.timestamp(joinPath(path, "effectiveDate"), t.EffectiveDate)
+		doc.Term.LeaseContractLength.Duration = t.LeaseContractLength.Duration
+		doc.Term.LeaseContractLength.Unit = t.LeaseContractLength.Unit
+		for j, rate := range t.Rates {
+			price, parseErr := strconv.ParseFloat(rate.DiscountedRate.Price, 64)
+			if parseErr != nil {
+				errs.add(&PriceParseError{Path: joinPath(path, "rates", strconv.Itoa(j), "discountedRate", "price"), Value: rate.DiscountedRate.Price, Err: parseErr})
+				continue
+			}
+			doc.Term.Rates = append(doc.Term.Rates, SavingsPlanRate{
+				RateCode:              rate.RateCode,
+				DiscountedSKU:         rate.DiscountedSKU,
+				DiscountedUsageType:   rate.DiscountedUsageType,
+				DiscountedOperation:   rate.DiscountedOperation,
+				DiscountedServiceCode: rate.DiscountedServiceCode,
+				Unit:                  rate.Unit,
+				Currency:              rate.DiscountedRate.Currency,
+				Price:                 price,
+			})
+		}
+		if err = errs.err(t.SKU); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		return docs[i].Product.SKU < docs[j].Product.SKU
+	})
+	return docs, nil
+}
+
+// SavingsPlanPrice is the price of usage of a PricingDocument when covered by a Savings Plan
+type SavingsPlanPrice struct {
+	SavingsPlanSKU string
+	ProductFamily  string
+	PurchaseTerm   string
+	PurchaseOption string
+	RateCode       string
+	Unit           string
+	Currency       string
+	Price          float64
+}
+
+// SavingsPlanIndex indexes the rates of Savings Plan documents by the usage they discount
+// It holds prices by value and each call to Prices returns a new slice, so one index can serve many goroutines
+type SavingsPlanIndex struct {
+	bySKU   map[string][]SavingsPlanPrice
+	byUsage map[[2]string][]SavingsPlanPrice
+}
+
+// NewSavingsPlanIndex indexes the rates of the Savings Plan documents
+func NewSavingsPlanIndex(docs []SavingsPlanDocument) *SavingsPlanIndex {
+	idx := &SavingsPlanIndex{
+		bySKU:   make(map[string][]SavingsPlanPrice),
+		byUsage: make(map[[2]string][]SavingsPlanPrice),
+	}
+	for _, doc := range docs {
+		for _, rate := range doc.Term.Rates {
+			price := SavingsPlanPrice{
+				SavingsPlanSKU: doc.Product.SKU,
+				ProductFamily:  doc.Product.ProductFamily,
+				PurchaseTerm:   doc.Product.Attributes.PurchaseTerm,
+				PurchaseOption: doc.Product.Attributes.PurchaseOption,
+				RateCode:       rate.RateCode,
+				Unit:           rate.Unit,
+				Currency:       rate.Currency,
+				Price:          rate.Price,
+			}
+			if rate.DiscountedSKU != "" {
+				idx.bySKU[rate.DiscountedSKU] = append(idx.bySKU[rate.DiscountedSKU], price)
+				continue
+			}
+			usage := [2]string{rate.DiscountedUsageType, rate.DiscountedOperation}
+			idx.byUsage[usage] = append(idx.byUsage[usage], price)
+		}
+	}
+	return idx
+}
+
+// Prices returns the Savings Plan prices of the document's usage in the currency, matching rates by the
+// discounted SKU or, for rates without one, by usage type and operation, ordered by product family,
+// purchase term and purchase option
+func (idx *SavingsPlanIndex) Prices(doc PricingDocument, currency string) (prices []SavingsPlanPrice) {
+	attrs := doc.Product.Attributes
+	for _, candidates := range [][]SavingsPlanPrice{
+		idx.bySKU[doc.Product.SKU],
+		idx.byUsage[[2]string{attrs.UsageType, attrs.Operation}],
+	} {
+		for _, price := range candidates {
+			if price.Currency == currency {
+				prices = append(prices, price)
+			}
+		}
+	}
+	sort.SliceStable(prices, func(i, j int) bool {
+		a, b := prices[i], prices[j]
+		if a.ProductFamily != b.ProductFamily {
+			return a.ProductFamily < b.ProductFamily
+		}
+		if a.PurchaseTerm != b.PurchaseTerm {
+			return a.PurchaseTerm < b.PurchaseTerm
+		}
+		return a.PurchaseOption < b.PurchaseOption
+	})
+	return prices
+}
+
+// PriceComparison is the hourly on-demand price of a document alongside its Reserved and Savings Plan prices
+type PriceComparison struct {
+	SKU            string
+	Currency       string
+	OnDemandHourly float64
+	ReservedOffers []ReservedOffer
+	SavingsPlans   []SavingsPlanPrice
+}
+
+// Compare returns the document's on-demand, Reserved and Savings Plan prices in the currency
+func (idx *SavingsPlanIndex) Compare(doc PricingDocument, currency string) (comparison PriceComparison, err error) {
+	comparison.SKU = doc.Product.SKU
+	comparison.Currency = currency
+	if comparison.OnDemandHourly, err = doc.OnDemandHourly(currency); err != nil {
+		return comparison, err
+	}
+	comparison.ReservedOffers = doc.ReservedOffers(currency)
+	comparison.SavingsPlans = idx.Prices(doc, currency)
+	return comparison, nil
+}
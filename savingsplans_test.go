@@ -0,0 +1,103 @@
+package awsPricingTyper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const mockSavingsPlanOfferFile = `{
+  "version": "20200226201510",
+  "publicationDate": "2020-02-26T20:15:10Z",
+  "regionCode": "eu-west-1",
+  "products": [
+    {"sku": "EC2SP1YRNOUPFRONT", "productFamily": "EC2InstanceSavingsPlans", "serviceCode": "ComputeSavingsPlans", "usageType": "EC2SP:m4.1yrNoUpfront",
+     "attributes": {"purchaseOption": "No Upfront", "granularity": "hourly", "instanceType": "m4", "locationType": "AWS Region", "location": "EU (Ireland)", "purchaseTerm": "1yr"}},
+    {"sku": "COMPUTESP3YRALLUPFRONT", "productFamily": "ComputeSavingsPlans", "serviceCode": "ComputeSavingsPlans", "usageType": "ComputeSP:3yrAllUpfront",
+     "attributes": {"purchaseOption": "All Upfront", "granularity": "hourly", "locationType": "AWS Region", "location": "Any", "purchaseTerm": "3yr"}}
+  ],
+  "terms": {
+    "savingsPlan": [
+      {"sku": "EC2SP1YRNOUPFRONT", "description": "1 year No Upfront EC2 Instance Savings Plan", "effectiveDate": "2020-02-26T20:15:10Z",
+       "leaseContractLength": {"duration": 1, "unit": "year"},
+       "rates": [
+         {"discountedSku": "7X4K64YA59VZZAC3", "discountedUsageType": "EU-BoxUsage:m4.large", "discountedOperation": "RunInstances",
+          "discountedServiceCode": "AmazonEC2", "rateCode": "EC2SP1YRNOUPFRONT.7X4K64YA59VZZAC3", "unit": "Hrs", "discountedRate": {"price": "0.0772", "currency": "USD"}}
+       ]},
+      {"sku": "COMPUTESP3YRALLUPFRONT", "description": "3 year All Upfront Compute Savings Plan", "effectiveDate": "2020-02-26T20:15:10Z",
+       "leaseContractLength": {"duration": 3, "unit": "year"},
+       "rates": [
+         {"discountedUsageType": "EU-BoxUsage:m4.large", "discountedOperation": "RunInstances",
+          "discountedServiceCode": "AmazonEC2", "rateCode": "COMPUTESP3YRALLUPFRONT.EUBOXM4L", "unit": "Hrs", "discountedRate": {"price": "0.0541", "currency": "USD"}},
+         {"discountedSku": "OTHERSKU", "discountedUsageType": "EU-BoxUsage:m4.xlarge", "discountedOperation": "RunInstances",
+          "discountedServiceCode": "AmazonEC2", "rateCode": "COMPUTESP3YRALLUPFRONT.OTHERSKU", "unit": "Hrs", "discountedRate": {"price": "0.1082", "currency": "USD"}}
+       ]}
+    ]
+  }
+}`
+
+func TestReadSavingsPlanOfferFile(t *testing.T) {
+	docs, err := ReadSavingsPlanOfferFile(strings.NewReader(mockSavingsPlanOfferFile))
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if len(docs) != 2 || docs[0].Product.SKU != "COMPUTESP3YRALLUPFRONT" || docs[1].Product.ProductFamily != SavingsPlanFamilyEC2Instance {
+		t.Fatalf("unexpected documents: %+v", docs)
+	}
+	ec2 := docs[1]
+	if ec2.RegionCode != "eu-west-1" || ec2.Product.Attributes.InstanceType != "m4" || ec2.Term.LeaseContractLength.Duration != 1 || ec2.Term.EffectiveTime.IsZero() {
+		t.Errorf("unexpected document: %+v", ec2)
+	}
+	if len(ec2.Term.Rates) != 1 || ec2.Term.Rates[0].Price != 0.0772 || ec2.Term.Rates[0].DiscountedSKU != "7X4K64YA59VZZAC3" {
+		t.Errorf("unexpected rates: %+v", ec2.Term.Rates)
+	}
+
+	_, err = ReadSavingsPlanOfferFile(strings.NewReader(strings.Replace(mockSavingsPlanOfferFile, `"0.0772"`, `"cheap"`, 1)))
+	var priceParse *PriceParseError
+	if !errors.As(err, &priceParse) || priceParse.SKU != "EC2SP1YRNOUPFRONT" || priceParse.Path != "terms.savingsPlan.0.rates.0.discountedRate.price" {
+		t.Errorf("expected price parse error but got: %+v", err)
+	}
+
+	var timestampParse *TimestampParseError
+	_, err = ReadSavingsPlanOfferFile(strings.NewReader(strings.Replace(mockSavingsPlanOfferFile,
+		`"effectiveDate": "2020-02-26T20:15:10Z"`, `"effectiveDate": "yesterday"`, 1)))
+	if !errors.As(err, &timestampParse) || timestampParse.SKU != "EC2SP1YRNOUPFRONT" || timestampParse.Path != "terms.savingsPlan.0.effectiveDate" {
+		t.Errorf("expected effective date parse error but got: %+v", err)
+	}
+	_, err = ReadSavingsPlanOfferFile(strings.NewReader(strings.Replace(mockSavingsPlanOfferFile,
+		`"publicationDate": "2020-02-26T20:15:10Z"`, `"publicationDate": "nope"`, 1)))
+	if !errors.As(err, &timestampParse) || timestampParse.Path != "publicationDate" || timestampParse.Value != "nope" {
+		t.Errorf("expected publication date parse error but got: %+v", err)
+	}
+	if _, err = ReadSavingsPlanOfferFile(strings.NewReader(`{"version": "1"}`)); err == nil {
+		t.Error("expected error for offer file without products")
+	}
+}
+
+func TestSavingsPlanIndexCompare(t *testing.T) {
+	docs, err := ReadSavingsPlanOfferFile(strings.NewReader(mockSavingsPlanOfferFile))
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	idx := NewSavingsPlanIndex(docs)
+	comparison, err := idx.Compare(getMockPricingDocuments(t)[0], CurrencyUSD)
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if comparison.OnDemandHourly != 0.111 || len(comparison.ReservedOffers) != 1 || comparison.ReservedOffers[0].HourlyFee != 0.0756 {
+		t.Errorf("unexpected on demand and reserved prices: %+v", comparison)
+	}
+	if len(comparison.SavingsPlans) != 2 {
+		t.Fatalf("expected 2 savings plan prices but got: %+v", comparison.SavingsPlans)
+	}
+	compute, ec2 := comparison.SavingsPlans[0], comparison.SavingsPlans[1]
+	if compute.ProductFamily != SavingsPlanFamilyCompute || compute.PurchaseTerm != "3yr" || compute.PurchaseOption != "All Upfront" || compute.Price != 0.0541 {
+		t.Errorf("unexpected compute savings plan price: %+v", compute)
+	}
+	if ec2.ProductFamily != SavingsPlanFamilyEC2Instance || ec2.SavingsPlanSKU != "EC2SP1YRNOUPFRONT" || ec2.Price != 0.0772 {
+		t.Errorf("unexpected ec2 instance savings plan price: %+v", ec2)
+	}
+	if prices := idx.Prices(getMockPricingDocuments(t)[0], CurrencyCNY); len(prices) != 0 {
+		t.Errorf("expected no prices in CNY but got: %+v", prices)
+	}
+}
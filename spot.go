@@ -0,0 +1,146 @@
+package awsPricingTyper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// SpotPrice is a record of EC2 spot price history
+type SpotPrice struct {
+	InstanceType     string
+	AvailabilityZone string
+	// ProductDescription is the platform of the price, e.g. Linux/UNIX or Windows (Amazon VPC)
+	ProductDescription string
+	Price              float64
+	Timestamp          time.Time
+}
+
+// SpotPriceSource provides spot price history
+type SpotPriceSource interface {
+	SpotPrices() ([]SpotPrice, error)
+}
+
+// EC2SpotPriceSource is a SpotPriceSource that calls DescribeSpotPriceHistory
+type EC2SpotPriceSource struct {
+	Client ec2iface.EC2API
+	Input  ec2.DescribeSpotPriceHistoryInput
+}
+
+// SpotPrices returns every page of spot price history matching the input
+func (s EC2SpotPriceSource) SpotPrices() (prices []SpotPrice, err error) {
+	var convErr error
+	err = s.Client.DescribeSpotPriceHistoryPages(&s.Input, func(page *ec2.DescribeSpotPriceHistoryOutput, lastPage bool) bool {
+		var pagePrices []SpotPrice
+		pagePrices, convErr = spotPricesFromEC2(page.SpotPriceHistory)
+		prices = append(prices, pagePrices...)
+		return convErr == nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe spot price history: %+v", err)
+	}
+	if convErr != nil {
+		return nil, convErr
+	}
+	return prices, nil
+}
+
+// JSONSpotPriceSource is a SpotPriceSource that reads DescribeSpotPriceHistory output saved as JSON,
+// e.g. by `aws ec2 describe-spot-price-history`
+type JSONSpotPriceSource struct {
+	Reader io.Reader
+}
+
+// SpotPrices returns the spot price history read from the JSON
+func (s JSONSpotPriceSource) SpotPrices() ([]SpotPrice, error) {
+	var output ec2.DescribeSpotPriceHistoryOutput
+	if err := json.NewDecoder(s.Reader).Decode(&output); err != nil {
+		return nil, fmt.Errorf("failed to decode spot price history: %+v", err)
+	}
+	return spotPricesFromEC2(output.SpotPriceHistory)
+}
+
+func spotPricesFromEC2(history []*ec2.SpotPrice) (prices []SpotPrice, err error) {
+	for _, sp := range history {
+		if sp == nil {
+			continue
+		}
+		price := SpotPrice{
+			InstanceType:       aws.StringValue(sp.InstanceType),
+			AvailabilityZone:   aws.StringValue(sp.AvailabilityZone),
+			ProductDescription: aws.StringValue(sp.ProductDescription),
+			Timestamp:          aws.TimeValue(sp.Timestamp),
+		}
+		if price.Price, err = strconv.ParseFloat(aws.StringValue(sp.SpotPrice), 64); err != nil {
+			return nil, fmt.Errorf("failed to parse spot price %q of %s in %s: %+v",
+				aws.StringValue(sp.SpotPrice), price.InstanceType, price.AvailabilityZone, err)
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+// SpotDiscount is a spot price joined to the on-demand price of the same instance
+type SpotDiscount struct {
+	SpotPrice
+	SKU            string
+	OnDemandHourly float64
+	// DiscountPercent is how much cheaper the spot price is than on-demand
+	DiscountPercent float64
+}
+
+// SpotDiscounts joins spot prices to the catalog's running, shared tenancy documents of the same instance type,
+// region and platform and returns the discount of each versus the on-demand price in the currency
+// Spot prices without a matching document, e.g. of a region or platform unknown to the library, are returned as unmatched
+func SpotDiscounts(catalog *Catalog, prices []SpotPrice, currency string) (discounts []SpotDiscount, unmatched []SpotPrice) {
+	for _, sp := range prices {
+		doc, err := spotPriceDocument(catalog, sp)
+		if err != nil {
+			unmatched = append(unmatched, sp)
+			continue
+		}
+		onDemand, err := doc.OnDemandHourly(currency)
+		if err != nil || onDemand <= 0 {
+			unmatched = append(unmatched, sp)
+			continue
+		}
+		discounts = append(discounts, SpotDiscount{
+			SpotPrice:       sp,
+			SKU:             doc.Product.SKU,
+			OnDemandHourly:  onDemand,
+			DiscountPercent: (onDemand - sp.Price) / onDemand * 100,
+		})
+	}
+	return discounts, unmatched
+}
+
+func spotPriceDocument(catalog *Catalog, sp SpotPrice) (PricingDocument, error) {
+	region, ok := availabilityZoneRegion(sp.AvailabilityZone)
+	if !ok {
+		return PricingDocument{}, fmt.Errorf("unknown region of availability zone: %s", sp.AvailabilityZone)
+	}
+	platform, ok := PlatformByDetails(strings.TrimSuffix(sp.ProductDescription, " (Amazon VPC)"))
+	if !ok {
+		return PricingDocument{}, fmt.Errorf("unknown product description: %s", sp.ProductDescription)
+	}
+	return catalog.lookupRunning(InstanceSpec{
+		InstanceType:    sp.InstanceType,
+		Location:        region.Location,
+		OperatingSystem: platform.OperatingSystem,
+		Tenancy:         TenancyShared,
+		PreInstalledSw:  platform.PreInstalledSw,
+		Operation:       platform.Operation,
+	})
+}
+
+// availabilityZoneRegion returns the region of an availability zone, e.g. eu-west-1 for eu-west-1a
+func availabilityZoneRegion(az string) (Region, bool) {
+	return RegionByCode(strings.TrimRight(az, "abcdefghijklmnopqrstuvwxyz"))
+}
@@ -0,0 +1,83 @@
+package awsPricingTyper
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+type mockEC2Client struct {
+	ec2iface.EC2API
+	pages []*ec2.DescribeSpotPriceHistoryOutput
+}
+
+func (m *mockEC2Client) DescribeSpotPriceHistoryPages(input *ec2.DescribeSpotPriceHistoryInput, fn func(*ec2.DescribeSpotPriceHistoryOutput, bool) bool) error {
+	for i, page := range m.pages {
+		if !fn(page, i == len(m.pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func readMockSpotPrices(t *testing.T) []SpotPrice {
+	f, err := os.Open("testdata/spot-price-history.json")
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	defer f.Close()
+	prices, err := JSONSpotPriceSource{Reader: f}.SpotPrices()
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	return prices
+}
+
+func TestSpotDiscounts(t *testing.T) {
+	prices := readMockSpotPrices(t)
+	if len(prices) != 4 || prices[0].Price != 0.0333 || prices[0].Timestamp.Hour() != 1 {
+		t.Fatalf("unexpected spot prices: %+v", prices)
+	}
+	catalog, err := NewCatalog(getMockPricingDocuments(t))
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+
+	discounts, unmatched := SpotDiscounts(catalog, prices, CurrencyUSD)
+	if len(discounts) != 2 || len(unmatched) != 2 {
+		t.Fatalf("expected 2 discounts and 2 unmatched but got: %+v %+v", discounts, unmatched)
+	}
+	if discounts[0].SKU != "7X4K64YA59VZZAC3" || discounts[0].OnDemandHourly != 0.111 || !almostEqual(discounts[0].DiscountPercent, 70) {
+		t.Errorf("unexpected discount: %+v", discounts[0])
+	}
+	if discounts[1].AvailabilityZone != "eu-west-1b" || !almostEqual(discounts[1].DiscountPercent, 60) {
+		t.Errorf("unexpected discount: %+v", discounts[1])
+	}
+	if unmatched[0].ProductDescription != "Windows" || unmatched[1].AvailabilityZone != "xx-fake-9a" {
+		t.Errorf("unexpected unmatched spot prices: %+v", unmatched)
+	}
+}
+
+func TestEC2SpotPriceSource(t *testing.T) {
+	source := EC2SpotPriceSource{Client: &mockEC2Client{pages: []*ec2.DescribeSpotPriceHistoryOutput{
+		{SpotPriceHistory: []*ec2.SpotPrice{{InstanceType: aws.String("m4.large"), AvailabilityZone: aws.String("eu-west-1a"), SpotPrice: aws.String("0.0333")}}},
+		{SpotPriceHistory: []*ec2.SpotPrice{{InstanceType: aws.String("m4.xlarge"), AvailabilityZone: aws.String("eu-west-1a"), SpotPrice: aws.String("0.0666")}}},
+	}}}
+	prices, err := source.SpotPrices()
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	if len(prices) != 2 || prices[1].InstanceType != "m4.xlarge" || prices[1].Price != 0.0666 {
+		t.Errorf("unexpected spot prices: %+v", prices)
+	}
+
+	source.Client = &mockEC2Client{pages: []*ec2.DescribeSpotPriceHistoryOutput{
+		{SpotPriceHistory: []*ec2.SpotPrice{{InstanceType: aws.String("m4.large"), SpotPrice: aws.String("cheap")}}},
+	}}
+	if _, err = source.SpotPrices(); err == nil {
+		t.Error("expected error for bad spot price")
+	}
+}
@@ -0,0 +1,74 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tenancies implied by usage types, matching the tenancy attribute of products
+const (
+	TenancyShared    = "Shared"
+	TenancyDedicated = "Dedicated"
+	TenancyHost      = "Host"
+)
+
+// usageKindTenancies maps the instance usage kinds to the tenancy they imply
+var usageKindTenancies = map[string]string{
+	"BoxUsage":       TenancyShared,
+	"SpotUsage":      TenancyShared,
+	"UnusedBox":      TenancyShared,
+	"DedicatedUsage": TenancyDedicated,
+	"UnusedDed":      TenancyDedicated,
+	"HostUsage":      TenancyHost,
+}
+
+// usEast1 is the region whose usage types are not prefixed
+const usEast1 = "us-east-1"
+
+// UsageType is a decomposed instance usage type, e.g. EU-BoxUsage:m4.large
+type UsageType struct {
+	Raw string
+	// RegionPrefix is the prefix of the usage type, e.g. EU, and is empty for us-east-1 usage types without one
+	RegionPrefix string
+	RegionCode   string
+	// Kind is the kind of usage, e.g. BoxUsage, DedicatedUsage or HostUsage
+	Kind string
+	// InstanceType is empty for usage of a whole host, e.g. HostUsage:m5
+	InstanceType   string
+	InstanceFamily string
+	Tenancy        string
+}
+
+// ParseUsageType decomposes an instance usage type such as EU-BoxUsage:m4.large,
+// USE1-DedicatedUsage:c5.xlarge or APN1-HostUsage:m5
+func ParseUsageType(usageType string) (UsageType, error) {
+	ut := UsageType{Raw: usageType, RegionCode: usEast1}
+	usage, instance, ok := strings.Cut(usageType, ":")
+	if !ok || instance == "" {
+		return UsageType{}, fmt.Errorf("usage type %q is not for an instance", usageType)
+	}
+	ut.Kind = usage
+	if prefix, kind, found := strings.Cut(usage, "-"); found {
+		region, known := RegionByUsageTypePrefix(prefix)
+		if !known {
+			return UsageType{}, fmt.Errorf("unknown region prefix %q of usage type %q", prefix, usageType)
+		}
+		ut.RegionPrefix = prefix
+		ut.RegionCode = region.Code
+		ut.Kind = kind
+	}
+	if ut.Tenancy, ok = usageKindTenancies[ut.Kind]; !ok {
+		return UsageType{}, fmt.Errorf("unknown usage kind %q of usage type %q", ut.Kind, usageType)
+	}
+	ut.InstanceFamily = instance
+	if family, _, isType := strings.Cut(instance, "."); isType {
+		ut.InstanceType = instance
+		ut.InstanceFamily = family
+	}
+	return ut, nil
+}
+
+// UsageType returns the document's decomposed usage type
+func (doc PricingDocument) UsageType() (UsageType, error) {
+	return ParseUsageType(doc.Product.Attributes.UsageType)
+}
@@ -0,0 +1,44 @@
+package awsPricingTyper
+
+import "testing"
+
+func TestParseUsageType(t *testing.T) {
+	tests := []struct {
+		usageType string
+		expected  UsageType
+	}{
+		{"EU-BoxUsage:m4.large", UsageType{RegionPrefix: "EU", RegionCode: "eu-west-1", Kind: "BoxUsage", InstanceType: "m4.large", InstanceFamily: "m4", Tenancy: TenancyShared}},
+		{"USE1-DedicatedUsage:c5.xlarge", UsageType{RegionPrefix: "USE1", RegionCode: "us-east-1", Kind: "DedicatedUsage", InstanceType: "c5.xlarge", InstanceFamily: "c5", Tenancy: TenancyDedicated}},
+		{"APN1-HostUsage:m5", UsageType{RegionPrefix: "APN1", RegionCode: "ap-northeast-1", Kind: "HostUsage", InstanceFamily: "m5", Tenancy: TenancyHost}},
+		{"BoxUsage:t2.micro", UsageType{RegionCode: "us-east-1", Kind: "BoxUsage", InstanceType: "t2.micro", InstanceFamily: "t2", Tenancy: TenancyShared}},
+	}
+	for _, test := range tests {
+		test.expected.Raw = test.usageType
+		got, err := ParseUsageType(test.usageType)
+		if err != nil {
+			t.Errorf("got error: %+v", err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("%s: expected %+v but got: %+v", test.usageType, test.expected, got)
+		}
+	}
+
+	for _, usageType := range []string{"", "EU-DataTransfer-Out-Bytes", "XX-BoxUsage:m4.large", "EU-EBS:VolumeUsage.gp2", "EU-BoxUsage:"} {
+		if _, err := ParseUsageType(usageType); err == nil {
+			t.Errorf("expected error for usage type: %q", usageType)
+		}
+	}
+}
+
+func TestDocumentUsageType(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	ut, err := doc.UsageType()
+	if err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+	region, _ := RegionByLocation(doc.Product.Attributes.Location)
+	if ut.RegionCode != region.Code || ut.InstanceType != doc.Product.Attributes.InstanceType || ut.Tenancy != doc.Product.Attributes.Tenancy {
+		t.Errorf("usage type %+v does not agree with the product attributes", ut)
+	}
+}
@@ -0,0 +1,94 @@
+package awsPricingTyper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// units the price dimensions of the supported product families are priced in
+var validUnits = []string{"Hrs", "Quantity"}
+
+// ValidationError is returned by Validate for each inconsistency found in a PricingDocument
+type ValidationError struct {
+	SKU     string
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s%s: %s", e.Path, skuSuffix(e.SKU), e.Message)
+}
+
+// Validate checks a PricingDocument for consistency, reporting every problem found as a
+// ValidationError in a MultiError, or returning nil if the document is valid
+// It checks that every term has the SKU of the product, that offer term and rate codes are of
+// the form SKU.OFFER.RATE, that publication and effective dates are timestamps, that units are known and that
+// Reserved terms have all of their term attributes
+func Validate(doc PricingDocument) error {
+	var errs errorList
+	sku := doc.Product.SKU
+	if sku == "" {
+		errs.add(&ValidationError{Path: "product.sku", Message: "missing"})
+	}
+	if doc.SKU != "" && doc.SKU != sku {
+		errs.add(&ValidationError{Path: "sku", Message: fmt.Sprintf("%q does not match product sku %q", doc.SKU, sku)})
+	}
+	if doc.PublicationDate != "" {
+		if _, err := time.Parse(time.RFC3339, doc.PublicationDate); err != nil {
+			errs.add(&ValidationError{Path: "publicationDate", Message: fmt.Sprintf("%q is not a timestamp", doc.PublicationDate)})
+		}
+	}
+	for _, code := range sortedOnDemandTermCodes(doc.Terms.OnDemand) {
+		term := doc.Terms.OnDemand[code]
+		path := joinPath("terms", TermTypeOnDemand, code)
+		validateTerm(&errs, path, sku, code, term.SKU, term.OfferTermCode, term.EffectiveDate, term.PriceDimensions)
+	}
+	for _, code := range sortedReservedTermCodes(doc.Terms.Reserved) {
+		term := doc.Terms.Reserved[code]
+		path := joinPath("terms", TermTypeReserved, code)
+		validateTerm(&errs, path, sku, code, term.SKU, term.OfferTermCode, term.EffectiveDate, term.PriceDimensions)
+		attributes := map[string]string{
+			"LeaseContractLength": term.TermAttributes.LeaseContractLength,
+			"OfferingClass":       term.TermAttributes.OfferingClass,
+			"PurchaseOption":      term.TermAttributes.PurchaseOption,
+		}
+		for _, name := range []string{"LeaseContractLength", "OfferingClass", "PurchaseOption"} {
+			if attributes[name] == "" {
+				errs.add(&ValidationError{Path: joinPath(path, "termAttributes", name), Message: "missing"})
+			}
+		}
+	}
+	return errs.err(sku)
+}
+
+func validateTerm(errs *errorList, path, sku, code, termSKU, offerTermCode, effectiveDate string, priceDimensions []PriceDimension) {
+	if termSKU != sku {
+		errs.add(&ValidationError{Path: joinPath(path, "sku"), Message: fmt.Sprintf("%q does not match product sku %q", termSKU, sku)})
+	}
+	if offerTermCode == "" || strings.Contains(offerTermCode, ".") {
+		errs.add(&ValidationError{Path: joinPath(path, "offerTermCode"), Message: fmt.Sprintf("%q is not a valid offer term code", offerTermCode)})
+	}
+	if expected := sku + "." + offerTermCode; code != expected {
+		errs.add(&ValidationError{Path: path, Message: fmt.Sprintf("term code %q should be %q", code, expected)})
+	}
+	if _, err := time.Parse(time.RFC3339, effectiveDate); err != nil {
+		errs.add(&ValidationError{Path: joinPath(path, "effectiveDate"), Message: fmt.Sprintf("%q is not a timestamp", effectiveDate)})
+	}
+	for _, pd := range priceDimensions {
+		for _, rateCode := range sortedPriceDimensionRateCodes(pd) {
+			item := pd[rateCode]
+			pdPath := joinPath(path, "priceDimensions", rateCode)
+			if item.RateCode != rateCode {
+				errs.add(&ValidationError{Path: joinPath(pdPath, "rateCode"), Message: fmt.Sprintf("%q does not match price dimension %q", item.RateCode, rateCode)})
+			}
+			parts := strings.Split(item.RateCode, ".")
+			if len(parts) != 3 || parts[0] != sku || parts[1] != offerTermCode || parts[2] == "" {
+				errs.add(&ValidationError{Path: joinPath(pdPath, "rateCode"), Message: fmt.Sprintf("%q should be of the form %s.%s.RATE", item.RateCode, sku, offerTermCode)})
+			}
+			if !stringInSlice(item.Unit, validUnits, false) {
+				errs.add(&ValidationError{Path: joinPath(pdPath, "unit"), Message: fmt.Sprintf("%q is not a known unit", item.Unit)})
+			}
+		}
+	}
+}
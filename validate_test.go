@@ -0,0 +1,63 @@
+package awsPricingTyper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	doc := getMockPricingDocuments(t)[0]
+	// the mock's OnDemand price dimension is keyed differently to its rate code
+	err := Validate(doc)
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 1 {
+		t.Fatalf("expected one validation error but got: %+v", err)
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error but got: %+v", err)
+	}
+	expectedPath := "terms.OnDemand.7X4K64YA59VZZAC3.JRTCKXETXF.priceDimensions.ABCDEFGHIJK.LMNOPQRST.UVWXYZ.rateCode"
+	if validationErr.Path != expectedPath || validationErr.SKU != "7X4K64YA59VZZAC3" {
+		t.Errorf("unexpected validation error: %+v", validationErr)
+	}
+
+	onDemand := doc.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"]
+	pd := onDemand.PriceDimensions[0]["ABCDEFGHIJK.LMNOPQRST.UVWXYZ"]
+	onDemand.PriceDimensions = []PriceDimension{{pd.RateCode: pd}}
+	doc.Terms.OnDemand["7X4K64YA59VZZAC3.JRTCKXETXF"] = onDemand
+	if err = Validate(doc); err != nil {
+		t.Fatalf("got error: %+v", err)
+	}
+
+	reserved := doc.Terms.Reserved["7X4K64YA59VZZAC3.4NA7Y494T4"]
+	reserved.SKU = "OTHERSKU"
+	reserved.EffectiveDate = "yesterday"
+	reserved.TermAttributes.OfferingClass = ""
+	for _, pd := range reserved.PriceDimensions {
+		for rateCode, item := range pd {
+			item.Unit = "Fortnights"
+			pd[rateCode] = item
+		}
+	}
+	doc.Terms.Reserved["7X4K64YA59VZZAC3.4NA7Y494T4"] = reserved
+	err = Validate(doc)
+	if !errors.As(err, &multi) {
+		t.Fatalf("expected validation errors but got: %+v", err)
+	}
+	path := "terms.Reserved.7X4K64YA59VZZAC3.4NA7Y494T4"
+	expectedPaths := []string{
+		path + ".sku",
+		path + ".effectiveDate",
+		path + ".priceDimensions.7X4K64YA59VZZAC3.4NA7Y494T4.6YS6EN2CT7.unit",
+		path + ".termAttributes.OfferingClass",
+	}
+	if len(multi.Errors) != len(expectedPaths) {
+		t.Fatalf("expected %d validation errors but got: %+v", len(expectedPaths), multi.Errors)
+	}
+	for i, expected := range expectedPaths {
+		if got := multi.Errors[i].(*ValidationError).Path; got != expected {
+			t.Errorf("expected error %d to have path %s but got: %s", i, expected, got)
+		}
+	}
+}